@@ -0,0 +1,185 @@
+package polaris
+
+import (
+	"errors"
+	"fmt"
+	"math"
+	"sort"
+)
+
+// earthRadius is the radius in meters of the spherical Earth used to convert
+// meters to degrees, the same as the one of distance.HaversineDistance.
+const earthRadius = 6371000
+
+// BoundingBox is an area between two parallels and two meridians, given by
+// its south-western and north-eastern corners.
+//
+// A box whose SouthWest longitude is greater than its NorthEast longitude
+// crosses the antimeridian: the box from longitude 170 to -170 is 20° wide and
+// contains longitude 180. A box spanning all longitudes has the longitudes
+// -180 and 180.
+type BoundingBox struct {
+	SouthWest Position
+	NorthEast Position
+}
+
+// NewBoundingBox returns the smallest box that contains all positions. The
+// longitudes are treated as a circle, so positions on both sides of the
+// antimeridian produce a box that crosses it rather than one spanning the
+// whole globe. It returns an error if there are no positions or one of them
+// fails [Position.Validate].
+func NewBoundingBox(positions []Position) (BoundingBox, error) {
+	if len(positions) == 0 {
+		return BoundingBox{}, errors.New("bounding box needs at least one position")
+	}
+
+	south, north := 90.0, -90.0
+	lons := make([]float64, len(positions))
+	for i, p := range positions {
+		if err := p.Validate(); err != nil {
+			return BoundingBox{}, fmt.Errorf("position %d: %w", i, err)
+		}
+		south = math.Min(south, p.Latitude)
+		north = math.Max(north, p.Latitude)
+		lons[i] = p.Normalize().Longitude
+	}
+	sort.Float64s(lons)
+
+	// The box leaves out the largest gap between successive longitudes. The
+	// gap across the antimeridian wins ties so that boxes only cross it when
+	// that makes them smaller.
+	west := lons[0]
+	gap := lons[0] + 360 - lons[len(lons)-1]
+	for i := 1; i < len(lons); i++ {
+		if d := lons[i] - lons[i-1]; d > gap {
+			gap, west = d, lons[i]
+		}
+	}
+	return newBoundingBox(south, north, west, 360-gap), nil
+}
+
+// NewBoundingBoxAround returns the smallest box that contains the circle of
+// the given radius in meters around center on a sphere with a radius of
+// 6,371 km. If the circle contains a pole, the box spans all longitudes.
+func NewBoundingBoxAround(center Position, meters float64) BoundingBox {
+	return BoundingBox{SouthWest: center, NorthEast: center}.Expand(meters)
+}
+
+// newBoundingBox returns the box between the given latitudes that starts at
+// longitude west and extends span degrees to the east.
+func newBoundingBox(south, north, west, span float64) BoundingBox {
+	if span >= 360 {
+		return BoundingBox{SouthWest: NewPosition(south, -180), NorthEast: NewPosition(north, 180)}
+	}
+	west = NewPosition(0, west).Normalize().Longitude
+	east := west + span
+	if east > 180 {
+		east -= 360
+	}
+	return BoundingBox{SouthWest: NewPosition(south, west), NorthEast: NewPosition(north, east)}
+}
+
+// String returns the box in "south,west,north,east" format.
+func (b BoundingBox) String() string {
+	return fmt.Sprintf("%f,%f,%f,%f", b.SouthWest.Latitude, b.SouthWest.Longitude, b.NorthEast.Latitude, b.NorthEast.Longitude)
+}
+
+// CrossesAntimeridian reports whether the box contains the meridian at ±180°
+// in its interior, which is the case if its western longitude is greater than
+// its eastern one.
+func (b BoundingBox) CrossesAntimeridian() bool {
+	return b.SouthWest.Longitude > b.NorthEast.Longitude
+}
+
+// span returns the width of the box in degrees of longitude.
+func (b BoundingBox) span() float64 {
+	d := b.NorthEast.Longitude - b.SouthWest.Longitude
+	if d < 0 {
+		d += 360
+	}
+	return d
+}
+
+// Center returns the center of the box. For boxes that cross the
+// antimeridian it lies between the corners across the antimeridian.
+func (b BoundingBox) Center() Position {
+	lat := (b.SouthWest.Latitude + b.NorthEast.Latitude) / 2
+	lon := b.SouthWest.Longitude + b.span()/2
+	return NewPosition(lat, NewPosition(0, lon).Normalize().Longitude)
+}
+
+// Contains reports whether p lies inside the box or on its edges.
+func (b BoundingBox) Contains(p Position) bool {
+	if p.Latitude < b.SouthWest.Latitude || p.Latitude > b.NorthEast.Latitude {
+		return false
+	}
+	return lonOffset(b.SouthWest.Longitude, p.Longitude) <= b.span() || p.Longitude == b.NorthEast.Longitude
+}
+
+// Intersects reports whether the boxes share at least one point, including
+// points on their edges.
+func (b BoundingBox) Intersects(o BoundingBox) bool {
+	if b.NorthEast.Latitude < o.SouthWest.Latitude || o.NorthEast.Latitude < b.SouthWest.Latitude {
+		return false
+	}
+	// Two longitude intervals overlap if one of them contains the western
+	// edge of the other.
+	return lonOffset(b.SouthWest.Longitude, o.SouthWest.Longitude) <= b.span() ||
+		lonOffset(o.SouthWest.Longitude, b.SouthWest.Longitude) <= o.span()
+}
+
+// Union returns the smallest box that contains both boxes. Like
+// [NewBoundingBox], it crosses the antimeridian if that gives a smaller box.
+func (b BoundingBox) Union(o BoundingBox) BoundingBox {
+	south := math.Min(b.SouthWest.Latitude, o.SouthWest.Latitude)
+	north := math.Max(b.NorthEast.Latitude, o.NorthEast.Latitude)
+
+	// Candidate longitude intervals start at the western edge of either box
+	// and reach the farther eastern edge. The smaller one containing both
+	// boxes wins.
+	best := 360.0
+	west := -180.0
+	for _, c := range [][2]BoundingBox{{b, o}, {o, b}} {
+		first, second := c[0], c[1]
+		span := math.Max(first.span(), lonOffset(first.SouthWest.Longitude, second.SouthWest.Longitude)+second.span())
+		if span < best {
+			best, west = span, first.SouthWest.Longitude
+		}
+	}
+	return newBoundingBox(south, north, west, best)
+}
+
+// Expand returns the box grown by the given distance in meters on every side,
+// on a sphere with a radius of 6,371 km. The east and west sides move by the
+// distance at the latitude of the box closest to a pole, so the result
+// contains every point within that distance of the box. A box that reaches a
+// pole spans all longitudes. Negative distances are treated as zero.
+func (b BoundingBox) Expand(meters float64) BoundingBox {
+	d := math.Max(meters, 0) / earthRadius * 180 / math.Pi
+
+	south := b.SouthWest.Latitude - d
+	north := b.NorthEast.Latitude + d
+	if south <= -90 || north >= 90 {
+		return newBoundingBox(math.Max(south, -90), math.Min(north, 90), -180, 360)
+	}
+
+	// The longitude extent of a spherical cap of angular radius d around a
+	// corner at latitude lat is asin(sin d / cos lat).
+	lat := math.Max(math.Abs(b.SouthWest.Latitude), math.Abs(b.NorthEast.Latitude)) * math.Pi / 180
+	s := math.Sin(d*math.Pi/180) / math.Cos(lat)
+	if s >= 1 {
+		return newBoundingBox(south, north, -180, 360)
+	}
+	dLon := math.Asin(s) * 180 / math.Pi
+	return newBoundingBox(south, north, b.SouthWest.Longitude-dLon, b.span()+2*dLon)
+}
+
+// lonOffset returns how many degrees east of west the longitude lon lies, in
+// [0, 360).
+func lonOffset(west, lon float64) float64 {
+	d := math.Mod(lon-west, 360)
+	if d < 0 {
+		d += 360
+	}
+	return d
+}
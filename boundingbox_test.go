@@ -0,0 +1,224 @@
+package polaris
+
+import (
+	"math"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func box(south, west, north, east float64) BoundingBox {
+	return BoundingBox{SouthWest: NewPosition(south, west), NorthEast: NewPosition(north, east)}
+}
+
+func assertBox(t *testing.T, want, got BoundingBox, delta float64) {
+	t.Helper()
+	assert.InDelta(t, want.SouthWest.Latitude, got.SouthWest.Latitude, delta, "south")
+	assert.InDelta(t, want.SouthWest.Longitude, got.SouthWest.Longitude, delta, "west")
+	assert.InDelta(t, want.NorthEast.Latitude, got.NorthEast.Latitude, delta, "north")
+	assert.InDelta(t, want.NorthEast.Longitude, got.NorthEast.Longitude, delta, "east")
+}
+
+func TestNewBoundingBox(t *testing.T) {
+	tests := []struct {
+		name      string
+		positions []Position
+		want      BoundingBox
+	}{
+		{
+			name:      "single position",
+			positions: []Position{NewPosition(47.3769, 8.5417)},
+			want:      box(47.3769, 8.5417, 47.3769, 8.5417),
+		},
+		{
+			name:      "swiss cities",
+			positions: []Position{NewPosition(47.3769, 8.5417), NewPosition(46.9480, 7.4474), NewPosition(46.2044, 6.1432)},
+			want:      box(46.2044, 6.1432, 47.3769, 8.5417),
+		},
+		{
+			name:      "across the antimeridian",
+			positions: []Position{NewPosition(-16.5, 179.5), NewPosition(-18.1, 178.4), NewPosition(-17.5, -179.6)},
+			want:      box(-18.1, 178.4, -16.5, -179.6),
+		},
+		{
+			name:      "longitude 180 normalized",
+			positions: []Position{NewPosition(0, 180), NewPosition(1, 179)},
+			want:      box(0, 179, 1, 180),
+		},
+		{
+			name:      "wide box prefers not to cross",
+			positions: []Position{NewPosition(0, -90), NewPosition(0, 90)},
+			want:      box(0, -90, 0, 90),
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := NewBoundingBox(tt.positions)
+			require.NoError(t, err)
+			assertBox(t, tt.want, got, 1e-12)
+			for _, p := range tt.positions {
+				assert.True(t, got.Contains(p), "%v", p)
+			}
+		})
+	}
+
+	_, err := NewBoundingBox(nil)
+	assert.Error(t, err)
+	_, err = NewBoundingBox([]Position{NewPosition(0, 0), NewPosition(91, 0)})
+	assert.ErrorIs(t, err, ErrInvalidLatitude)
+}
+
+func TestNewBoundingBoxAround(t *testing.T) {
+	// One degree of latitude on the sphere.
+	degree := earthRadius * math.Pi / 180
+
+	tests := []struct {
+		name   string
+		center Position
+		meters float64
+		want   BoundingBox
+	}{
+		{name: "equator", center: NewPosition(0, 0), meters: degree, want: box(-1, -1, 1, 1)},
+		{name: "60 north", center: NewPosition(60, 10), meters: degree, want: box(59, 7.9997, 61, 12.0003)},
+		{name: "antimeridian", center: NewPosition(0, 179.5), meters: degree, want: box(-1, 178.5, 1, -179.5)},
+		{name: "pole", center: NewPosition(89.5, 0), meters: degree, want: box(88.5, -180, 90, 180)},
+		{name: "zero radius", center: NewPosition(10, 20), meters: 0, want: box(10, 20, 10, 20)},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := NewBoundingBoxAround(tt.center, tt.meters)
+			assertBox(t, tt.want, got, 0.01)
+			assert.True(t, got.Contains(tt.center))
+		})
+	}
+}
+
+func TestBoundingBox_Contains(t *testing.T) {
+	swiss := box(45.8, 5.9, 47.8, 10.5)
+	fiji := box(-21, 177, -12, -178)
+	world := box(-90, -180, 90, 180)
+
+	tests := []struct {
+		name string
+		box  BoundingBox
+		pos  Position
+		want bool
+	}{
+		{name: "inside", box: swiss, pos: NewPosition(47.3769, 8.5417), want: true},
+		{name: "on the edge", box: swiss, pos: NewPosition(45.8, 5.9), want: true},
+		{name: "north", box: swiss, pos: NewPosition(48, 8), want: false},
+		{name: "east", box: swiss, pos: NewPosition(47, 11), want: false},
+		{name: "crossing east part", box: fiji, pos: NewPosition(-17, 178), want: true},
+		{name: "crossing west part", box: fiji, pos: NewPosition(-17, -179), want: true},
+		{name: "crossing at 180", box: fiji, pos: NewPosition(-17, 180), want: true},
+		{name: "crossing at -180", box: fiji, pos: NewPosition(-17, -180), want: true},
+		{name: "crossing outside", box: fiji, pos: NewPosition(-17, 0), want: false},
+		{name: "world", box: world, pos: NewPosition(-90, 123), want: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.want, tt.box.Contains(tt.pos))
+		})
+	}
+}
+
+func TestBoundingBox_Intersects(t *testing.T) {
+	swiss := box(45.8, 5.9, 47.8, 10.5)
+	fiji := box(-21, 177, -12, -178)
+
+	tests := []struct {
+		name string
+		a, b BoundingBox
+		want bool
+	}{
+		{name: "same", a: swiss, b: swiss, want: true},
+		{name: "overlap", a: swiss, b: box(47, 10, 49, 12), want: true},
+		{name: "contained", a: swiss, b: box(46, 7, 47, 8), want: true},
+		{name: "touching", a: swiss, b: box(47.8, 10.5, 50, 12), want: true},
+		{name: "apart in latitude", a: swiss, b: box(48, 6, 49, 10), want: false},
+		{name: "apart in longitude", a: swiss, b: box(46, 11, 47, 12), want: false},
+		{name: "crossing and west part", a: fiji, b: box(-20, -179, -19, -170), want: true},
+		{name: "crossing and east part", a: box(-20, 170, -19, 178), b: fiji, want: true},
+		{name: "both crossing", a: fiji, b: box(-15, 179, -10, -179), want: true},
+		{name: "crossing apart", a: fiji, b: box(-20, -170, -19, 170), want: false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.want, tt.a.Intersects(tt.b))
+			assert.Equal(t, tt.want, tt.b.Intersects(tt.a))
+		})
+	}
+}
+
+func TestBoundingBox_Union(t *testing.T) {
+	tests := []struct {
+		name string
+		a, b BoundingBox
+		want BoundingBox
+	}{
+		{name: "disjoint", a: box(0, 0, 1, 1), b: box(2, 3, 4, 5), want: box(0, 0, 4, 5)},
+		{name: "contained", a: box(0, 0, 10, 10), b: box(2, 3, 4, 5), want: box(0, 0, 10, 10)},
+		{name: "across the antimeridian", a: box(0, 170, 1, 175), b: box(0, -175, 1, -170), want: box(0, 170, 1, -170)},
+		{name: "crossing and west", a: box(0, 170, 1, -170), b: box(0, -160, 1, -150), want: box(0, 170, 1, -150)},
+		{name: "covering all longitudes", a: box(0, -180, 1, 0), b: box(0, 0, 1, 180), want: box(0, -180, 1, 180)},
+		{name: "overlapping across the antimeridian", a: box(0, 170, 1, -10), b: box(0, -20, 1, 160), want: box(0, 170, 1, 160)},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assertBox(t, tt.want, tt.a.Union(tt.b), 1e-12)
+			assertBox(t, tt.want, tt.b.Union(tt.a), 1e-12)
+		})
+	}
+}
+
+func TestBoundingBox_Expand(t *testing.T) {
+	degree := earthRadius * math.Pi / 180
+
+	tests := []struct {
+		name   string
+		box    BoundingBox
+		meters float64
+		want   BoundingBox
+	}{
+		{name: "equator", box: box(-1, -1, 1, 1), meters: degree, want: box(-2, -2.0003, 2, 2.0003)},
+		{name: "to the antimeridian", box: box(0, 170, 1, 179.5), meters: degree, want: box(-1, 169, 2, -179.5)},
+		{name: "over a pole", box: box(80, 0, 89.5, 10), meters: degree, want: box(79, -180, 90, 180)},
+		{name: "negative", box: box(0, 0, 1, 1), meters: -5, want: box(0, 0, 1, 1)},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assertBox(t, tt.want, tt.box.Expand(tt.meters), 0.001)
+		})
+	}
+}
+
+func TestBoundingBox_ExpandContainsCircle(t *testing.T) {
+	// Points at the given distance from the box stay inside the expanded box.
+	b := box(60, 10, 62, 14)
+	expanded := b.Expand(50000)
+	angle := 50000.0 / earthRadius
+	for _, corner := range []Position{b.SouthWest, b.NorthEast, NewPosition(62, 10), NewPosition(60, 14)} {
+		lat1 := corner.Latitude * math.Pi / 180
+		for bearing := 0.0; bearing < 360; bearing += 10 {
+			theta := bearing * math.Pi / 180
+			lat2 := math.Asin(math.Sin(lat1)*math.Cos(angle) + math.Cos(lat1)*math.Sin(angle)*math.Cos(theta))
+			dLon := math.Atan2(math.Sin(theta)*math.Sin(angle)*math.Cos(lat1), math.Cos(angle)-math.Sin(lat1)*math.Sin(lat2))
+			p := NewPosition(lat2*180/math.Pi, corner.Longitude+dLon*180/math.Pi)
+			assert.True(t, expanded.Contains(p), "%v", p)
+		}
+	}
+}
+
+func TestBoundingBox_Center(t *testing.T) {
+	assert.Equal(t, NewPosition(1, 2), box(0, 0, 2, 4).Center())
+	assert.Equal(t, NewPosition(0, -180), box(-1, 170, 1, -170).Center())
+	assert.Equal(t, NewPosition(0, 175), box(-1, 170, 1, -180).Center())
+	assert.Equal(t, NewPosition(0, 0), box(-90, -180, 90, 180).Center())
+}
+
+func TestBoundingBox_CrossesAntimeridian(t *testing.T) {
+	assert.True(t, box(0, 170, 1, -170).CrossesAntimeridian())
+	assert.False(t, box(0, -170, 1, 170).CrossesAntimeridian())
+	assert.False(t, box(0, -180, 1, 180).CrossesAntimeridian())
+}
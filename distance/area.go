@@ -0,0 +1,83 @@
+package distance
+
+import (
+	"math"
+
+	"github.com/ethz-polymaps/polaris"
+)
+
+// KarneyArea returns the area in square meters of the polygon on the WGS-84
+// ellipsoid whose vertices are given by ring and whose edges are geodesics.
+// The ring is closed implicitly, so its last position should not repeat the
+// first one.
+//
+// The area is positive if the vertices run counterclockwise around it and
+// negative if they run clockwise. Of the two parts the ring divides the
+// ellipsoid into, the result is the one smaller than half of the surface, so
+// rings around a pole and across the antimeridian need no special treatment.
+// Rings with fewer than three positions have an area of zero.
+func KarneyArea(ring []polaris.Position) float64 {
+	return wgs84Geodesic.polygonArea(ring)
+}
+
+// polygonArea sums the areas between the edges of the ring and the equator,
+// following the algorithm of GeographicLib's PolygonArea.
+func (g *geodesic) polygonArea(ring []polaris.Position) float64 {
+	if len(ring) < 3 {
+		return 0
+	}
+
+	// Accumulate the edge areas in double-double precision, since the sum is
+	// much smaller than its terms for small polygons.
+	var sum, comp float64
+	crossings := 0
+	for i, a := range ring {
+		b := ring[(i+1)%len(ring)]
+		_, _, _, S12 := g.genInverse(a.Latitude, a.Longitude, b.Latitude, b.Longitude, true)
+		y, u := errorFreeSum(S12, comp)
+		sum, comp = errorFreeSum(y, sum)
+		if sum == 0 {
+			sum = u
+		} else {
+			comp += u
+		}
+		crossings += transit(a.Longitude, b.Longitude)
+	}
+
+	// The edge areas are measured from the equator. A ring that encircles a
+	// pole crosses the prime meridian an odd number of times and needs to be
+	// corrected by half of the surface.
+	area0 := 4 * math.Pi * g.c2
+	area := math.Remainder(sum+comp, area0)
+	if crossings&1 != 0 {
+		if area < 0 {
+			area += area0 / 2
+		} else {
+			area -= area0 / 2
+		}
+	}
+	// The sum runs clockwise; turn it counterclockwise.
+	area = -area
+	if area > area0/2 {
+		area -= area0
+	} else if area <= -area0/2 {
+		area += area0
+	}
+	return area
+}
+
+// transit returns 1 if the edge from lon1 to lon2 crosses the prime meridian
+// (longitude 0) eastwards, -1 if it crosses westwards and 0 otherwise.
+func transit(lon1, lon2 float64) int {
+	lon12, _ := angDiff(lon1, lon2)
+	lon1 = angNormalize(lon1)
+	lon2 = angNormalize(lon2)
+	switch {
+	case lon12 > 0 && ((lon1 < 0 && lon2 >= 0) || (lon1 > 0 && lon2 == 0)):
+		return 1
+	case lon12 < 0 && lon1 >= 0 && lon2 < 0:
+		return -1
+	default:
+		return 0
+	}
+}
@@ -0,0 +1,95 @@
+package distance
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/ethz-polymaps/polaris"
+)
+
+func TestKarneyArea(t *testing.T) {
+	ring := func(coords ...[2]float64) []polaris.Position {
+		r := make([]polaris.Position, len(coords))
+		for i, c := range coords {
+			r[i] = polaris.NewPosition(c[0], c[1])
+		}
+		return r
+	}
+
+	// Reference values from the GeographicLib test suite (Planimeter).
+	tests := []struct {
+		name  string
+		ring  []polaris.Position
+		want  float64
+		delta float64
+	}{
+		{
+			name:  "around north pole",
+			ring:  ring([2]float64{89, 0}, [2]float64{89, 90}, [2]float64{89, 180}, [2]float64{89, 270}),
+			want:  24952305678.0,
+			delta: 1,
+		},
+		{
+			name:  "around south pole is clockwise",
+			ring:  ring([2]float64{-89, 0}, [2]float64{-89, 90}, [2]float64{-89, 180}, [2]float64{-89, 270}),
+			want:  -24952305678.0,
+			delta: 1,
+		},
+		{
+			name:  "diamond on equator",
+			ring:  ring([2]float64{0, -1}, [2]float64{-1, 0}, [2]float64{0, 1}, [2]float64{1, 0}),
+			want:  24619419146.0,
+			delta: 1,
+		},
+		{
+			name:  "octant",
+			ring:  ring([2]float64{90, 0}, [2]float64{0, 0}, [2]float64{0, 90}),
+			want:  63758202715511.0,
+			delta: 1,
+		},
+		{
+			name:  "octant clockwise",
+			ring:  ring([2]float64{0, 90}, [2]float64{0, 0}, [2]float64{90, 0}),
+			want:  -63758202715511.0,
+			delta: 1,
+		},
+		{
+			name:  "triangle around pole across antimeridian",
+			ring:  ring([2]float64{89, 0.1}, [2]float64{89, 90.1}, [2]float64{89, -179.9}),
+			want:  12476152838.5,
+			delta: 1,
+		},
+		{
+			name: "around pole with repeated longitudes",
+			ring: ring([2]float64{89, -360}, [2]float64{89, -240}, [2]float64{89, -120},
+				[2]float64{89, 0}, [2]float64{89, 120}, [2]float64{89, 240}),
+			want:  32415230256.0,
+			delta: 1,
+		},
+		{
+			name:  "too few positions",
+			ring:  ring([2]float64{47, 8}, [2]float64{48, 9}),
+			want:  0,
+			delta: 0,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.InDelta(t, tt.want, KarneyArea(tt.ring), tt.delta)
+		})
+	}
+}
+
+func TestKarneyArea_smallPolygon(t *testing.T) {
+	// A square of 0.001° at the equator is close to a plane rectangle of
+	// the meridian and equatorial arc lengths.
+	square := []polaris.Position{
+		polaris.NewPosition(0, 0),
+		polaris.NewPosition(0, 0.001),
+		polaris.NewPosition(0.001, 0.001),
+		polaris.NewPosition(0.001, 0),
+	}
+	want := KarneyDistance(square[0], square[1]) * KarneyDistance(square[1], square[2])
+	assert.InDelta(t, want, KarneyArea(square), 1e-3)
+}
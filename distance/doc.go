@@ -1,6 +1,6 @@
 // Package distance provides functions for calculating distances between geographic positions.
 //
-// This package implements two distance calculation methods:
+// This package implements three distance calculation methods:
 //
 // # Haversine Formula
 //
@@ -18,7 +18,16 @@
 // is more accurate than Haversine, especially for long distances and paths
 // that cross near the poles.
 //
-// Accuracy: Sub-millimeter precision for any distance on Earth.
+// Accuracy: Sub-millimeter precision for any distance on Earth. The iteration
+// may fail to converge for nearly antipodal points.
+//
+// # Karney Algorithm
+//
+// [KarneyDistance] solves the same ellipsoidal problem using Karney's series
+// algorithm. It converges for every pair of points on the WGS-84 ellipsoid,
+// including nearly antipodal ones.
+//
+// Accuracy: About 15 nanometers for any distance on Earth.
 //
 // # Choosing a Method
 //
@@ -32,5 +41,102 @@
 //   - Calculating long distances
 //   - Working with surveying or mapping applications
 //
-// Both functions return the distance in meters.
+// Use [KarneyDistance] when points may be nearly antipodal or when the result
+// must be reliable for arbitrary input.
+//
+// Use a [Ruler] when many distances are measured within one building, campus
+// or city. It approximates the ellipsoid by a plane around a reference
+// latitude and is about ten times faster than [HaversineDistance] while being
+// more accurate at these scales.
+//
+// All functions return the distance in meters.
+//
+// # Direct Problem
+//
+// [HaversineDestination] and [VincentyDestination] solve the reverse task:
+// given a start position, an initial bearing and a distance in meters, they
+// return the destination and the final bearing on arrival.
+//
+// # Intermediate Positions
+//
+// [HaversineIntermediate] and [VincentyIntermediate] return the position at a
+// fraction of the path between two positions, and [HaversineMidpoint] and
+// [VincentyMidpoint] the one halfway. [HaversineWaypoints] and
+// [VincentyWaypoints] divide a path into equal segments, for example to draw
+// it on a map, while [HaversineWaypointsEvery] and [VincentyWaypointsEvery]
+// place positions at a fixed spacing in meters to resample trajectories.
+//
+// # Paths
+//
+// [HaversineCrossTrackDistance] and [VincentyCrossTrackDistance] return how
+// far a position lies to the right (positive) or left (negative) of the path
+// through two positions, and [HaversineAlongTrackDistance] and
+// [VincentyAlongTrackDistance] how far along the path its closest point lies.
+// [HaversineClosestPoint] and [VincentyClosestPoint] return the closest point
+// of a segment. [HaversineSnapToPolyline] and [VincentySnapToPolyline] snap a
+// position to a path of several segments, for example to raise path-deviation
+// alerts:
+//
+//	snap := distance.VincentySnapToPolyline(estimate, corridor)
+//	if snap.Distance > 5 {
+//	    // ...
+//	}
+//
+// # Bearings
+//
+// [HaversineInverse], [VincentyInverse] and [KarneyInverse] return an [Inverse]
+// holding the distance together with the initial and final bearing, so that
+// direction-aware code solves each geodesic only once. Bearings are given in
+// degrees clockwise from north in the range [0, 360). The *InitialBearing and
+// *FinalBearing functions return a single bearing.
+//
+// # Area
+//
+// [KarneyArea] returns the area of a polygon with geodesic edges on the WGS-84
+// ellipsoid in square meters, signed by the orientation of its vertices. The
+// geometry package builds polygons with holes and point-in-polygon tests on
+// top of it.
+//
+// # Rhumb Lines
+//
+// [RhumbDistance], [RhumbBearing] and [RhumbDestination] follow rhumb lines,
+// the paths of constant compass heading flown by ships and some drones, on the
+// spherical Earth used by [HaversineDistance]. They take the shorter way
+// around the antimeridian, and rhumb lines that reach a pole end there.
+// [RhumbDistance] plugs into trilateration.WithDistanceFunc like the other
+// distance functions.
+//
+// # Altitude
+//
+// [SlantDistance] returns the straight-line distance between two
+// [polaris.Position3D] values, which is what radio ranging measures between
+// anchors and tags at different heights. [NewSlant] combines any ground
+// distance function with a fixed height difference and plugs into
+// trilateration.WithDistanceFunc, for example for anchors mounted 3 m above
+// tags at an altitude of 410 m:
+//
+//	slant := distance.NewSlant(distance.VincentyDistance, 410, 3)
+//
+// # Distance Matrices
+//
+// [Matrix] computes the distances between two sets of positions on a pool of
+// workers and returns them as a row-major gonum matrix, for example to pick
+// anchors or to cluster data. [SymmetricMatrix] computes the distances
+// within one set and evaluates only the upper triangle. Both stop when their
+// context is canceled; [WithWorkers] sets the number of workers:
+//
+//	m, err := distance.Matrix(ctx, anchors, tags, distance.VincentyDistance, distance.WithWorkers(8))
+//
+// # Earth Models
+//
+// The package-level functions use the WGS-84 ellipsoid or a sphere with a
+// radius of 6,371 km. Other models are described by an [Ellipsoid], with the
+// presets [WGS84], [GRS80] and [Bessel1841], or by a [Sphere], whose radius can
+// be derived from an ellipsoid as the mean, authalic or local radius of
+// curvature. [NewHaversine], [NewVincenty] and [NewKarney] return distance
+// functions for a given model that plug into trilateration.WithDistanceFunc:
+//
+//	t := trilateration.NewTrilaterator(
+//	    trilateration.WithDistanceFunc(distance.NewVincenty(distance.Bessel1841())),
+//	)
 package distance
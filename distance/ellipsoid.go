@@ -0,0 +1,169 @@
+package distance
+
+import (
+	"math"
+
+	"github.com/ethz-polymaps/polaris"
+)
+
+// Ellipsoid is an oblate ellipsoid of revolution used as a model of the Earth.
+type Ellipsoid struct {
+	// SemiMajorAxis is the equatorial radius a in meters.
+	SemiMajorAxis float64
+	// Flattening is f = (a - b) / a, where b is the polar radius.
+	Flattening float64
+}
+
+// Defining parameters of the reference ellipsoids.
+const (
+	wgs84SemiMajorAxis          = 6378137
+	wgs84InverseFlattening      = 298.257223563
+	grs80SemiMajorAxis          = 6378137
+	grs80InverseFlattening      = 298.257222101
+	bessel1841SemiMajorAxis     = 6377397.155
+	bessel1841InverseFlattening = 299.1528128
+)
+
+// WGS84 returns the World Geodetic System 1984 ellipsoid used by GPS.
+func WGS84() Ellipsoid {
+	return NewEllipsoid(wgs84SemiMajorAxis, wgs84InverseFlattening)
+}
+
+// GRS80 returns the Geodetic Reference System 1980 ellipsoid used by ETRS89.
+func GRS80() Ellipsoid {
+	return NewEllipsoid(grs80SemiMajorAxis, grs80InverseFlattening)
+}
+
+// Bessel1841 returns the Bessel ellipsoid used by the Swiss CH1903 and
+// CH1903+ datums.
+func Bessel1841() Ellipsoid {
+	return NewEllipsoid(bessel1841SemiMajorAxis, bessel1841InverseFlattening)
+}
+
+// NewEllipsoid creates an Ellipsoid from its semi-major axis in meters and its
+// inverse flattening 1/f, the form in which ellipsoids are usually published.
+func NewEllipsoid(semiMajorAxis, inverseFlattening float64) Ellipsoid {
+	return Ellipsoid{SemiMajorAxis: semiMajorAxis, Flattening: 1 / inverseFlattening}
+}
+
+// SemiMinorAxis returns the polar radius b in meters.
+func (e Ellipsoid) SemiMinorAxis() float64 {
+	return e.SemiMajorAxis * (1 - e.Flattening)
+}
+
+// EccentricitySquared returns the square of the first eccentricity, e² = f(2 - f).
+func (e Ellipsoid) EccentricitySquared() float64 {
+	return e.Flattening * (2 - e.Flattening)
+}
+
+// Cartesian converts geodetic latitude and longitude in degrees and the
+// height above the ellipsoid in meters to Earth-centered Cartesian
+// coordinates in meters. The X axis points to latitude 0° and longitude 0°
+// and the Z axis to the North Pole.
+func (e Ellipsoid) Cartesian(latitude, longitude, height float64) (x, y, z float64) {
+	e2 := e.EccentricitySquared()
+	sinLat, cosLat := math.Sincos(latitude * math.Pi / 180)
+	sinLon, cosLon := math.Sincos(longitude * math.Pi / 180)
+
+	n := e.SemiMajorAxis / math.Sqrt(1-e2*sinLat*sinLat)
+	x = (n + height) * cosLat * cosLon
+	y = (n + height) * cosLat * sinLon
+	z = (n*(1-e2) + height) * sinLat
+	return x, y, z
+}
+
+// MeanRadius returns the arithmetic mean radius (2a + b) / 3 in meters.
+func (e Ellipsoid) MeanRadius() float64 {
+	return (2*e.SemiMajorAxis + e.SemiMinorAxis()) / 3
+}
+
+// AuthalicRadius returns the radius in meters of the sphere with the same
+// surface area as the ellipsoid.
+func (e Ellipsoid) AuthalicRadius() float64 {
+	a, b := e.SemiMajorAxis, e.SemiMinorAxis()
+	e2 := e.EccentricitySquared()
+	if e2 == 0 {
+		return a
+	}
+	ecc := math.Sqrt(e2)
+	return math.Sqrt((a*a + b*b*math.Atanh(ecc)/ecc) / 2)
+}
+
+// LocalRadius returns the Gaussian radius of curvature in meters at the given
+// latitude in degrees, the geometric mean of the meridional and prime vertical
+// radii of curvature. A sphere with this radius fits the ellipsoid best in the
+// neighborhood of that latitude.
+func (e Ellipsoid) LocalRadius(latitude float64) float64 {
+	e2 := e.EccentricitySquared()
+	sinLat := math.Sin(latitude * math.Pi / 180)
+	return e.SemiMajorAxis * math.Sqrt(1-e2) / (1 - e2*sinLat*sinLat)
+}
+
+// MeanSphere returns the sphere with the ellipsoid's [Ellipsoid.MeanRadius].
+func (e Ellipsoid) MeanSphere() Sphere {
+	return Sphere{Radius: e.MeanRadius()}
+}
+
+// AuthalicSphere returns the sphere with the ellipsoid's [Ellipsoid.AuthalicRadius].
+func (e Ellipsoid) AuthalicSphere() Sphere {
+	return Sphere{Radius: e.AuthalicRadius()}
+}
+
+// LocalSphere returns the sphere with the ellipsoid's [Ellipsoid.LocalRadius]
+// at the given latitude in degrees.
+func (e Ellipsoid) LocalSphere(latitude float64) Sphere {
+	return Sphere{Radius: e.LocalRadius(latitude)}
+}
+
+// Sphere is a spherical model of the Earth.
+type Sphere struct {
+	// Radius is the radius of the sphere in meters.
+	Radius float64
+}
+
+// earthRadius is the radius of [SphericalEarth] in meters.
+const earthRadius = 6371000
+
+// SphericalEarth returns the sphere with a radius of 6,371 km used by
+// [HaversineDistance] and the other Haversine functions.
+func SphericalEarth() Sphere {
+	return Sphere{Radius: earthRadius}
+}
+
+// NewSphere creates a Sphere with the given radius in meters.
+func NewSphere(radius float64) Sphere {
+	return Sphere{Radius: radius}
+}
+
+// NewHaversine returns a distance function that uses the Haversine formula on
+// the given sphere. The result can be passed to trilateration.WithDistanceFunc:
+//
+//	t := trilateration.NewTrilaterator(
+//	    trilateration.WithDistanceFunc(distance.NewHaversine(distance.WGS84().LocalSphere(47.4))),
+//	)
+func NewHaversine(s Sphere) func(a, b polaris.Position) float64 {
+	return s.Distance
+}
+
+// NewVincenty returns a distance function that uses Vincenty's formulae on the
+// given ellipsoid. The result can be passed to trilateration.WithDistanceFunc:
+//
+//	t := trilateration.NewTrilaterator(
+//	    trilateration.WithDistanceFunc(distance.NewVincenty(distance.Bessel1841())),
+//	)
+func NewVincenty(e Ellipsoid) func(a, b polaris.Position) float64 {
+	return func(a, b polaris.Position) float64 {
+		g, _ := vincentySolve(e, a, b)
+		return g.distance
+	}
+}
+
+// NewKarney returns a distance function that uses Karney's algorithm on the
+// given ellipsoid. The result can be passed to trilateration.WithDistanceFunc.
+func NewKarney(e Ellipsoid) func(a, b polaris.Position) float64 {
+	g := newGeodesic(e.SemiMajorAxis, e.Flattening)
+	return func(a, b polaris.Position) float64 {
+		s12, _, _ := g.inverse(a.Latitude, a.Longitude, b.Latitude, b.Longitude)
+		return s12
+	}
+}
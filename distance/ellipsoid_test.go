@@ -0,0 +1,119 @@
+package distance
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/ethz-polymaps/polaris"
+)
+
+func TestEllipsoid(t *testing.T) {
+	tests := []struct {
+		name           string
+		ellipsoid      Ellipsoid
+		semiMinorAxis  float64
+		meanRadius     float64
+		authalicRadius float64
+	}{
+		{
+			name:           "wgs84",
+			ellipsoid:      WGS84(),
+			semiMinorAxis:  6356752.314245,
+			meanRadius:     6371008.771415,
+			authalicRadius: 6371007.180918,
+		},
+		{
+			name:           "grs80",
+			ellipsoid:      GRS80(),
+			semiMinorAxis:  6356752.314140,
+			meanRadius:     6371008.771380,
+			authalicRadius: 6371007.180883,
+		},
+		{
+			name:           "bessel 1841",
+			ellipsoid:      Bessel1841(),
+			semiMinorAxis:  6356078.962818,
+			meanRadius:     6370291.091,
+			authalicRadius: 6370289.510,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			e := tt.ellipsoid
+			assert.InDelta(t, tt.semiMinorAxis, e.SemiMinorAxis(), 0.000001)
+			assert.InDelta(t, tt.meanRadius, e.MeanRadius(), 0.001)
+			assert.InDelta(t, tt.authalicRadius, e.AuthalicRadius(), 0.001)
+
+			// The Gaussian radius is b at the equator and a²/b at the poles.
+			a, b := e.SemiMajorAxis, e.SemiMinorAxis()
+			assert.InDelta(t, b, e.LocalRadius(0), 0.000001)
+			assert.InDelta(t, a*a/b, e.LocalRadius(90), 0.000001)
+			assert.InDelta(t, a*a/b, e.LocalRadius(-90), 0.000001)
+
+			assert.Equal(t, e.MeanRadius(), e.MeanSphere().Radius)
+			assert.Equal(t, e.AuthalicRadius(), e.AuthalicSphere().Radius)
+			assert.Equal(t, e.LocalRadius(47), e.LocalSphere(47).Radius)
+		})
+	}
+}
+
+func TestEllipsoid_Cartesian(t *testing.T) {
+	a, b := WGS84().SemiMajorAxis, WGS84().SemiMinorAxis()
+	tests := []struct {
+		name                string
+		lat, lon, height    float64
+		wantX, wantY, wantZ float64
+	}{
+		{"origin", 0, 0, 0, a, 0, 0},
+		{"east", 0, 90, 0, 0, a, 0},
+		{"antimeridian", 0, 180, 100, -a - 100, 0, 0},
+		{"north pole", 90, 0, 0, 0, 0, b},
+		{"south pole", -90, 45, 10, 0, 0, -b - 10},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			x, y, z := WGS84().Cartesian(tt.lat, tt.lon, tt.height)
+			assert.InDelta(t, tt.wantX, x, 0.01)
+			assert.InDelta(t, tt.wantY, y, 0.01)
+			assert.InDelta(t, tt.wantZ, z, 0.01)
+		})
+	}
+}
+
+func TestNewDistanceFuncs(t *testing.T) {
+	zurich := polaris.NewPosition(47.3769, 8.5417)
+	bern := polaris.NewPosition(46.9480, 7.4474)
+	newYork := polaris.NewPosition(40.7128, -74.0060)
+
+	t.Run("haversine", func(t *testing.T) {
+		assert.Equal(t, HaversineDistance(zurich, bern), NewHaversine(SphericalEarth())(zurich, bern))
+		assert.Equal(t, HaversineDistance(zurich, bern), NewHaversine(NewSphere(6371000))(zurich, bern))
+
+		// Distances scale with the radius.
+		double := NewHaversine(NewSphere(2 * SphericalEarth().Radius))
+		assert.InDelta(t, 2*HaversineDistance(zurich, bern), double(zurich, bern), 0.000001)
+	})
+
+	t.Run("vincenty", func(t *testing.T) {
+		assert.InDelta(t, VincentyDistance(zurich, newYork), NewVincenty(WGS84())(zurich, newYork), 0.000001)
+	})
+
+	t.Run("karney", func(t *testing.T) {
+		assert.Equal(t, KarneyDistance(zurich, newYork), NewKarney(WGS84())(zurich, newYork))
+	})
+
+	t.Run("vincenty and karney agree on every ellipsoid", func(t *testing.T) {
+		for _, e := range []Ellipsoid{WGS84(), GRS80(), Bessel1841(), NewEllipsoid(6378388, 297)} {
+			assert.InDelta(t, NewKarney(e)(zurich, newYork), NewVincenty(e)(zurich, newYork), 0.0001)
+		}
+	})
+
+	t.Run("grs80 and wgs84 differ by less than a millimeter", func(t *testing.T) {
+		assert.InDelta(t, NewKarney(WGS84())(zurich, newYork), NewKarney(GRS80())(zurich, newYork), 0.001)
+	})
+
+	t.Run("bessel 1841 is smaller than wgs84", func(t *testing.T) {
+		assert.Less(t, NewKarney(Bessel1841())(zurich, bern), NewKarney(WGS84())(zurich, bern))
+	})
+}
@@ -1,6 +1,7 @@
 package distance_test
 
 import (
+	"context"
 	"fmt"
 
 	"github.com/ethz-polymaps/polaris"
@@ -43,3 +44,189 @@ func Example_compareDistanceMethods() {
 	// Vincenty:  5585.23 km
 	// Difference: -15.01 km
 }
+
+func ExampleKarneyDistance() {
+	// Wellington and Salamanca are nearly antipodal
+	wellington := polaris.NewPosition(-41.32, 174.81)
+	salamanca := polaris.NewPosition(40.96, -5.50)
+
+	dist := distance.KarneyDistance(wellington, salamanca)
+	fmt.Printf("Distance: %.3f km\n", dist/1000)
+	// Output:
+	// Distance: 19959.679 km
+}
+
+func ExampleHaversineDestination() {
+	zurich := polaris.NewPosition(47.3769, 8.5417)
+
+	// Travel 10 km due east
+	dest, finalBearing := distance.HaversineDestination(zurich, 90, 10000)
+	fmt.Printf("Destination: %.4f, %.4f\n", dest.Latitude, dest.Longitude)
+	fmt.Printf("Final bearing: %.2f°\n", finalBearing)
+	// Output:
+	// Destination: 47.3768, 8.6745
+	// Final bearing: 90.10°
+}
+
+func ExampleVincentyDestination() {
+	zurich := polaris.NewPosition(47.3769, 8.5417)
+
+	// Travel 10 km due east
+	dest, finalBearing := distance.VincentyDestination(zurich, 90, 10000)
+	fmt.Printf("Destination: %.4f, %.4f\n", dest.Latitude, dest.Longitude)
+	fmt.Printf("Final bearing: %.2f°\n", finalBearing)
+	// Output:
+	// Destination: 47.3768, 8.6741
+	// Final bearing: 90.10°
+}
+
+func ExampleVincentyInverse() {
+	zurich := polaris.NewPosition(47.3769, 8.5417)
+	bern := polaris.NewPosition(46.9480, 7.4474)
+
+	inv := distance.VincentyInverse(zurich, bern)
+	fmt.Printf("Distance: %.2f km\n", inv.Distance/1000)
+	fmt.Printf("Initial bearing: %.2f°\n", inv.InitialBearing)
+	fmt.Printf("Final bearing: %.2f°\n", inv.FinalBearing)
+	// Output:
+	// Distance: 95.70 km
+	// Initial bearing: 240.52°
+	// Final bearing: 239.72°
+}
+
+func ExampleNewVincenty() {
+	zurich := polaris.NewPosition(47.3769, 8.5417)
+	bern := polaris.NewPosition(46.9480, 7.4474)
+
+	// Swiss legacy data refers to the Bessel 1841 ellipsoid
+	bessel := distance.NewVincenty(distance.Bessel1841())
+	fmt.Printf("Bessel 1841: %.3f km\n", bessel(zurich, bern)/1000)
+	fmt.Printf("WGS-84:      %.3f km\n", distance.VincentyDistance(zurich, bern)/1000)
+	// Output:
+	// Bessel 1841: 95.688 km
+	// WGS-84:      95.699 km
+}
+
+func ExampleNewHaversine() {
+	zurich := polaris.NewPosition(47.3769, 8.5417)
+	bern := polaris.NewPosition(46.9480, 7.4474)
+
+	// A sphere fitted to the ellipsoid at the latitude of interest
+	local := distance.NewHaversine(distance.WGS84().LocalSphere(47))
+	fmt.Printf("Local sphere: %.2f km\n", local(zurich, bern)/1000)
+	// Output:
+	// Local sphere: 95.62 km
+}
+
+func ExampleHaversineMidpoint() {
+	zurich := polaris.NewPosition(47.3769, 8.5417)
+	newYork := polaris.NewPosition(40.7128, -74.0060)
+
+	mid := distance.HaversineMidpoint(zurich, newYork)
+	fmt.Printf("Midpoint: %.4f, %.4f\n", mid.Latitude, mid.Longitude)
+	// Output:
+	// Midpoint: 52.1171, -35.5617
+}
+
+func ExampleVincentyWaypointsEvery() {
+	zurich := polaris.NewPosition(47.3769, 8.5417)
+	bern := polaris.NewPosition(46.9480, 7.4474)
+
+	// Resample the path at a fixed spacing of 25 km.
+	path, err := distance.VincentyWaypointsEvery(zurich, bern, 25000)
+	if err != nil {
+		panic(err)
+	}
+	for _, p := range path {
+		fmt.Printf("%.4f, %.4f\n", p.Latitude, p.Longitude)
+	}
+	// Output:
+	// 47.3769, 8.5417
+	// 47.2659, 8.2541
+	// 47.1541, 7.9678
+	// 47.0417, 7.6826
+	// 46.9480, 7.4474
+}
+
+func ExampleHaversineCrossTrackDistance() {
+	p := polaris.NewPosition(53.2611, -0.7972)
+	start := polaris.NewPosition(53.3206, -1.7297)
+	end := polaris.NewPosition(53.1887, 0.1334)
+
+	fmt.Printf("Cross-track: %.1f m\n", distance.HaversineCrossTrackDistance(p, start, end))
+	fmt.Printf("Along-track: %.3f km\n", distance.HaversineAlongTrackDistance(p, start, end)/1000)
+	// Output:
+	// Cross-track: -307.5 m
+	// Along-track: 62.331 km
+}
+
+func ExampleVincentySnapToPolyline() {
+	path := []polaris.Position{
+		polaris.NewPosition(47.3769, 8.5417),
+		polaris.NewPosition(47.3780, 8.5400),
+		polaris.NewPosition(47.3800, 8.5410),
+	}
+	estimate := polaris.NewPosition(47.3777, 8.5412)
+
+	snap := distance.VincentySnapToPolyline(estimate, path)
+	fmt.Printf("Segment %d, %.2f m away at %.6f, %.6f\n",
+		snap.Segment, snap.Distance, snap.Position.Latitude, snap.Position.Longitude)
+	// Output:
+	// Segment 0, 38.35 m away at 47.377450, 8.540850
+}
+
+func ExampleRhumbDistance() {
+	dover := polaris.NewPosition(51.127, 1.338)
+	calais := polaris.NewPosition(50.964, 1.853)
+
+	fmt.Printf("Distance: %.2f km\n", distance.RhumbDistance(dover, calais)/1000)
+	fmt.Printf("Bearing: %.1f°\n", distance.RhumbBearing(dover, calais))
+	// Output:
+	// Distance: 40.31 km
+	// Bearing: 116.7°
+}
+
+func ExampleRuler() {
+	anchor := polaris.NewPosition(47.41331, 8.53644)
+	tag := polaris.NewPosition(47.41328, 8.53646)
+
+	ruler := distance.NewRuler(anchor.Latitude)
+	fmt.Printf("Ruler: %.4f m\n", ruler.Distance(anchor, tag))
+	fmt.Printf("Karney: %.4f m\n", distance.KarneyDistance(anchor, tag))
+	// Output:
+	// Ruler: 3.6610 m
+	// Karney: 3.6610 m
+}
+
+func ExampleMatrix() {
+	anchors := []polaris.Position{
+		polaris.NewPosition(47.3769, 8.5417),
+		polaris.NewPosition(46.9480, 7.4474),
+	}
+	tags := []polaris.Position{
+		polaris.NewPosition(46.2044, 6.1432),
+		polaris.NewPosition(47.5596, 7.5886),
+		polaris.NewPosition(46.0037, 8.9511),
+	}
+
+	m, err := distance.Matrix(context.Background(), anchors, tags, distance.VincentyDistance, distance.WithWorkers(2))
+	if err != nil {
+		panic(err)
+	}
+	for i := range anchors {
+		fmt.Printf("%.1f km, %.1f km, %.1f km\n", m.At(i, 0)/1000, m.At(i, 1)/1000, m.At(i, 2)/1000)
+	}
+	// Output:
+	// 224.8 km, 74.7 km, 155.8 km
+	// 129.7 km, 68.8 km, 156.1 km
+}
+
+func ExampleSlantDistance() {
+	anchor := polaris.NewPosition3D(47.41331, 8.53644, 412.5)
+	tag := polaris.NewPosition3D(47.41322, 8.53644, 409.0)
+	fmt.Printf("Ground: %.2f m\n", distance.KarneyDistance(anchor.Position, tag.Position))
+	fmt.Printf("Slant: %.2f m\n", distance.SlantDistance(anchor, tag))
+	// Output:
+	// Ground: 10.01 m
+	// Slant: 10.60 m
+}
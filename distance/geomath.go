@@ -0,0 +1,150 @@
+package distance
+
+import "math"
+
+// Numerical helpers for angles in degrees, following the conventions of
+// GeographicLib so that exact multiples of 90° are handled without rounding.
+
+// sq returns x squared.
+func sq(x float64) float64 {
+	return x * x
+}
+
+// norm scales (x, y) to unit length.
+func norm(x, y float64) (float64, float64) {
+	r := math.Hypot(x, y)
+	return x / r, y / r
+}
+
+// errorFreeSum returns the sum s of u and v together with the rounding error t,
+// such that s + t == u + v exactly.
+func errorFreeSum(u, v float64) (s, t float64) {
+	s = u + v
+	up := s - v
+	vpp := s - up
+	up -= u
+	vpp -= v
+	if s == 0 {
+		return s, s
+	}
+	return s, -(up + vpp)
+}
+
+// polyval evaluates the polynomial of degree n with coefficients p[s:s+n+1]
+// (highest degree first) at x using Horner's method.
+func polyval(n int, p []float64, s int, x float64) float64 {
+	if n < 0 {
+		return 0
+	}
+	y := p[s]
+	for ; n > 0; n-- {
+		s++
+		y = y*x + p[s]
+	}
+	return y
+}
+
+// angRound rounds tiny angles so that subsequent computations are exact for
+// values near zero.
+func angRound(x float64) float64 {
+	const z = 1.0 / 16
+	y := math.Abs(x)
+	if y < z {
+		y = z - (z - y)
+	}
+	return math.Copysign(y, x)
+}
+
+// angNormalize reduces an angle in degrees to the range [-180, 180].
+func angNormalize(x float64) float64 {
+	y := math.Remainder(x, 360)
+	if math.Abs(y) == 180 {
+		return math.Copysign(180, x)
+	}
+	return y
+}
+
+// normalizeBearing reduces a bearing in degrees to the range [0, 360).
+func normalizeBearing(x float64) float64 {
+	y := math.Mod(x, 360)
+	if y < 0 {
+		y += 360
+	}
+	if y == 360 {
+		return 0
+	}
+	return y + 0
+}
+
+// latFix returns NaN for latitudes outside [-90, 90].
+func latFix(x float64) float64 {
+	if math.Abs(x) > 90 {
+		return math.NaN()
+	}
+	return x
+}
+
+// angDiff returns the exact difference y - x reduced to [-180, 180] together
+// with its rounding error.
+func angDiff(x, y float64) (d, e float64) {
+	d, t := errorFreeSum(math.Remainder(-x, 360), math.Remainder(y, 360))
+	d, e = errorFreeSum(math.Remainder(d, 360), t)
+	if d == 0 || math.Abs(d) == 180 {
+		if e == 0 {
+			d = math.Copysign(d, y-x)
+		} else {
+			d = math.Copysign(d, -e)
+		}
+	}
+	return d, e
+}
+
+// sincosd returns the sine and cosine of an angle in degrees, exact for
+// multiples of 90°.
+func sincosd(x float64) (s, c float64) {
+	r := math.Mod(x, 360)
+	q := 0
+	if !math.IsNaN(r) {
+		q = int(math.RoundToEven(r / 90))
+	}
+	r -= 90 * float64(q)
+	r *= math.Pi / 180
+	s, c = math.Sin(r), math.Cos(r)
+	switch ((q % 4) + 4) % 4 {
+	case 1:
+		s, c = c, -s
+	case 2:
+		s, c = -s, -c
+	case 3:
+		s, c = -c, s
+	}
+	c += 0
+	if s == 0 {
+		s = math.Copysign(s, x)
+	}
+	return s, c
+}
+
+// atan2d returns atan2(y, x) in degrees in the range [-180, 180], exact for
+// multiples of 90°.
+func atan2d(y, x float64) float64 {
+	q := 0
+	if math.Abs(y) > math.Abs(x) {
+		q = 2
+		x, y = y, x
+	}
+	if x < 0 {
+		q++
+		x = -x
+	}
+	ang := math.Atan2(y, x) * 180 / math.Pi
+	switch q {
+	case 1:
+		ang = math.Copysign(180, y) - ang
+	case 2:
+		ang = 90 - ang
+	case 3:
+		ang = -90 + ang
+	}
+	return ang
+}
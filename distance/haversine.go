@@ -12,10 +12,14 @@ import (
 //
 // The Haversine formula is computationally efficient and provides good accuracy
 // for most applications. For higher precision over long distances, consider
-// using [VincentyDistance] instead.
+// using [VincentyDistance] instead. Use [NewHaversine] for a different radius.
 func HaversineDistance(a, b polaris.Position) float64 {
-	const R = 6371000 // Earth radius in meters
+	return SphericalEarth().Distance(a, b)
+}
 
+// Distance calculates the great-circle distance in meters between two points
+// on the sphere using the Haversine formula.
+func (s Sphere) Distance(a, b polaris.Position) float64 {
 	lat1Rad := a.Latitude * math.Pi / 180
 	lat2Rad := b.Latitude * math.Pi / 180
 	deltaLat := (b.Latitude - a.Latitude) * math.Pi / 180
@@ -25,5 +29,90 @@ func HaversineDistance(a, b polaris.Position) float64 {
 		math.Cos(lat1Rad)*math.Cos(lat2Rad)*
 			math.Sin(deltaLon/2)*math.Sin(deltaLon/2)
 
-	return R * 2 * math.Atan2(math.Sqrt(x), math.Sqrt(1-x))
+	return s.Radius * 2 * math.Atan2(math.Sqrt(x), math.Sqrt(1-x))
+}
+
+// HaversineInitialBearing returns the forward azimuth of the great circle from
+// a to b at a, in degrees clockwise from north in the range [0, 360). Bearings
+// on a sphere do not depend on its radius.
+func HaversineInitialBearing(a, b polaris.Position) float64 {
+	return HaversineInverse(a, b).InitialBearing
+}
+
+// HaversineFinalBearing returns the azimuth of the great circle from a to b
+// at b, in degrees clockwise from north in the range [0, 360). This is the
+// direction of travel on arrival.
+func HaversineFinalBearing(a, b polaris.Position) float64 {
+	return HaversineInverse(a, b).FinalBearing
+}
+
+// HaversineInverse returns the distance and both bearings between a and b on
+// the spherical Earth used by [HaversineDistance]. For coincident points the
+// bearings are zero.
+func HaversineInverse(a, b polaris.Position) Inverse {
+	return SphericalEarth().Inverse(a, b)
+}
+
+// Inverse returns the great-circle distance and both bearings between a and b
+// on the sphere. For coincident points the bearings are zero.
+func (s Sphere) Inverse(a, b polaris.Position) Inverse {
+	if a.Latitude == b.Latitude && a.Longitude == b.Longitude {
+		return Inverse{}
+	}
+
+	lat1 := a.Latitude * math.Pi / 180
+	lat2 := b.Latitude * math.Pi / 180
+	deltaLat := (b.Latitude - a.Latitude) * math.Pi / 180
+	deltaLon := (b.Longitude - a.Longitude) * math.Pi / 180
+
+	sinLat1, cosLat1 := math.Sin(lat1), math.Cos(lat1)
+	sinLat2, cosLat2 := math.Sin(lat2), math.Cos(lat2)
+	sinDeltaLon, cosDeltaLon := math.Sin(deltaLon), math.Cos(deltaLon)
+	sinHalfLat, sinHalfLon := math.Sin(deltaLat/2), math.Sin(deltaLon/2)
+
+	x := sinHalfLat*sinHalfLat + cosLat1*cosLat2*sinHalfLon*sinHalfLon
+
+	// The final bearing is the initial bearing from b to a turned around.
+	initial := math.Atan2(sinDeltaLon*cosLat2, cosLat1*sinLat2-sinLat1*cosLat2*cosDeltaLon)
+	final := math.Atan2(sinDeltaLon*cosLat1, cosLat1*sinLat2*cosDeltaLon-sinLat1*cosLat2)
+
+	return Inverse{
+		Distance:       s.Radius * 2 * math.Atan2(math.Sqrt(x), math.Sqrt(1-x)),
+		InitialBearing: normalizeBearing(initial * 180 / math.Pi),
+		FinalBearing:   normalizeBearing(final * 180 / math.Pi),
+	}
+}
+
+// HaversineDestination calculates the position reached by travelling the given
+// distance in meters along a great circle from start, leaving at initialBearing
+// degrees clockwise from north. It uses the same spherical Earth as
+// [HaversineDistance].
+//
+// It returns the destination and the final bearing in degrees in the range
+// [0, 360), which is the direction of travel on arrival.
+func HaversineDestination(start polaris.Position, initialBearing, meters float64) (dest polaris.Position, finalBearing float64) {
+	return SphericalEarth().Destination(start, initialBearing, meters)
+}
+
+// Destination calculates the position reached by travelling the given distance
+// in meters along a great circle of the sphere from start, leaving at
+// initialBearing degrees clockwise from north. It returns the destination and
+// the final bearing in degrees in the range [0, 360).
+func (s Sphere) Destination(start polaris.Position, initialBearing, meters float64) (dest polaris.Position, finalBearing float64) {
+	lat1 := start.Latitude * math.Pi / 180
+	theta := initialBearing * math.Pi / 180
+	delta := meters / s.Radius
+
+	sinLat1, cosLat1 := math.Sin(lat1), math.Cos(lat1)
+	sinDelta, cosDelta := math.Sin(delta), math.Cos(delta)
+	sinTheta, cosTheta := math.Sin(theta), math.Cos(theta)
+
+	sinLat2 := sinLat1*cosDelta + cosLat1*sinDelta*cosTheta
+	lat2 := math.Asin(sinLat2)
+	deltaLon := math.Atan2(sinTheta*sinDelta*cosLat1, cosDelta-sinLat1*sinLat2)
+
+	final := math.Atan2(sinTheta*cosLat1, cosDelta*cosLat1*cosTheta-sinLat1*sinDelta)
+
+	dest = polaris.NewPosition(lat2*180/math.Pi, angNormalize(start.Longitude+deltaLon*180/math.Pi))
+	return dest, normalizeBearing(final * 180 / math.Pi)
 }
@@ -1,6 +1,7 @@
 package distance
 
 import (
+	"math"
 	"testing"
 
 	"github.com/stretchr/testify/assert"
@@ -58,3 +59,138 @@ func TestCalculateHaversine(t *testing.T) {
 		})
 	}
 }
+
+func TestHaversineDestination(t *testing.T) {
+	type args struct {
+		start   polaris.Position
+		bearing float64
+		meters  float64
+	}
+	tests := []struct {
+		name         string
+		args         args
+		want         polaris.Position
+		finalBearing float64
+	}{
+		{
+			name: "zero distance",
+			args: args{
+				start:   polaris.NewPosition(47.413310, 8.536444),
+				bearing: 45,
+				meters:  0,
+			},
+			want:         polaris.NewPosition(47.413310, 8.536444),
+			finalBearing: 45,
+		},
+		{
+			name: "quarter great circle along the equator",
+			args: args{
+				start:   polaris.NewPosition(0, 0),
+				bearing: 90,
+				meters:  SphericalEarth().Radius * math.Pi / 2,
+			},
+			want:         polaris.NewPosition(0, 90),
+			finalBearing: 90,
+		},
+		{
+			name: "due north to the pole",
+			args: args{
+				start:   polaris.NewPosition(0, 10),
+				bearing: 0,
+				meters:  SphericalEarth().Radius * math.Pi / 2,
+			},
+			want:         polaris.NewPosition(90, 10),
+			finalBearing: 0,
+		},
+		{
+			name: "across the antimeridian",
+			args: args{
+				start:   polaris.NewPosition(0, 179.5),
+				bearing: 90,
+				meters:  SphericalEarth().Radius * math.Pi / 180,
+			},
+			want:         polaris.NewPosition(0, -179.5),
+			finalBearing: 90,
+		},
+		{
+			// Reference: Movable Type Scripts, "Calculate distance, bearing
+			// and more between Latitude/Longitude points".
+			name: "~124.8km",
+			args: args{
+				start:   polaris.NewPosition(53.32055556, -1.72972222),
+				bearing: 96.02166667,
+				meters:  124800,
+			},
+			want:         polaris.NewPosition(53.18826944, 0.13327778),
+			finalBearing: 97.51462222,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			dest, finalBearing := HaversineDestination(tt.args.start, tt.args.bearing, tt.args.meters)
+			assert.InDelta(t, tt.want.Latitude, dest.Latitude, 0.0001)
+			assert.InDelta(t, tt.want.Longitude, dest.Longitude, 0.0001)
+			assert.InDelta(t, tt.finalBearing, finalBearing, 0.0001)
+
+			// Travelling there and measuring back must give the distance.
+			assert.InDelta(t, tt.args.meters, HaversineDistance(tt.args.start, dest), 0.000001)
+		})
+	}
+}
+
+func TestHaversineInverse(t *testing.T) {
+	type args struct {
+		a polaris.Position
+		b polaris.Position
+	}
+	tests := []struct {
+		name string
+		args args
+		want Inverse
+	}{
+		{
+			name: "due east along the equator",
+			args: args{
+				a: polaris.NewPosition(0, 0),
+				b: polaris.NewPosition(0, 90),
+			},
+			want: Inverse{Distance: SphericalEarth().Radius * math.Pi / 2, InitialBearing: 90, FinalBearing: 90},
+		},
+		{
+			name: "due south along a meridian",
+			args: args{
+				a: polaris.NewPosition(10, 5),
+				b: polaris.NewPosition(-10, 5),
+			},
+			want: Inverse{Distance: SphericalEarth().Radius * math.Pi / 9, InitialBearing: 180, FinalBearing: 180},
+		},
+		{
+			name: "across the antimeridian",
+			args: args{
+				a: polaris.NewPosition(0, 179.5),
+				b: polaris.NewPosition(0, -179.5),
+			},
+			want: Inverse{Distance: SphericalEarth().Radius * math.Pi / 180, InitialBearing: 90, FinalBearing: 90},
+		},
+		{
+			name: "quarter great circle leaving north-east",
+			args: args{
+				a: polaris.NewPosition(0, 0),
+				b: polaris.NewPosition(45, 90),
+			},
+			want: Inverse{Distance: SphericalEarth().Radius * math.Pi / 2, InitialBearing: 45, FinalBearing: 90},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			result := HaversineInverse(tt.args.a, tt.args.b)
+			assert.InDelta(t, tt.want.Distance, result.Distance, 0.01)
+			assert.InDelta(t, tt.want.InitialBearing, result.InitialBearing, 0.0001)
+			assert.InDelta(t, tt.want.FinalBearing, result.FinalBearing, 0.0001)
+
+			assert.Equal(t, result.Distance, HaversineDistance(tt.args.a, tt.args.b))
+			assert.Equal(t, result.InitialBearing, HaversineInitialBearing(tt.args.a, tt.args.b))
+			assert.Equal(t, result.FinalBearing, HaversineFinalBearing(tt.args.a, tt.args.b))
+		})
+	}
+}
@@ -0,0 +1,165 @@
+package distance
+
+import (
+	"fmt"
+	"math"
+
+	"github.com/ethz-polymaps/polaris"
+)
+
+// HaversineIntermediate returns the position at the given fraction of the
+// great-circle path from a to b: a for 0, b for 1 and the midpoint for 0.5.
+// Fractions outside [0, 1] extend the great circle beyond a or b. The result
+// does not depend on the radius of the sphere.
+//
+// For antipodal points, where every great circle through a is a shortest
+// path, the path follows the meridian of a.
+func HaversineIntermediate(a, b polaris.Position, fraction float64) polaris.Position {
+	lat1, lon1 := a.Latitude*math.Pi/180, a.Longitude*math.Pi/180
+	lat2, lon2 := b.Latitude*math.Pi/180, b.Longitude*math.Pi/180
+
+	// Interpolate linearly between the unit vectors and project back onto
+	// the sphere.
+	x1, y1, z1 := math.Cos(lat1)*math.Cos(lon1), math.Cos(lat1)*math.Sin(lon1), math.Sin(lat1)
+	x2, y2, z2 := math.Cos(lat2)*math.Cos(lon2), math.Cos(lat2)*math.Sin(lon2), math.Sin(lat2)
+
+	delta := HaversineDistance(a, b) / earthRadius
+	sinDelta := math.Sin(delta)
+	if sinDelta < 1e-12 {
+		if delta < math.Pi/2 {
+			return a
+		}
+		dest, _ := HaversineDestination(a, 0, fraction*math.Pi*earthRadius)
+		return dest
+	}
+
+	wa := math.Sin((1-fraction)*delta) / sinDelta
+	wb := math.Sin(fraction*delta) / sinDelta
+	x := wa*x1 + wb*x2
+	y := wa*y1 + wb*y2
+	z := wa*z1 + wb*z2
+
+	lat := math.Atan2(z, math.Hypot(x, y)) * 180 / math.Pi
+	lon := math.Atan2(y, x) * 180 / math.Pi
+	return polaris.NewPosition(lat, lon)
+}
+
+// HaversineMidpoint returns the position halfway along the great-circle path
+// from a to b.
+func HaversineMidpoint(a, b polaris.Position) polaris.Position {
+	return HaversineIntermediate(a, b, 0.5)
+}
+
+// HaversineWaypoints divides the great-circle path from a to b into n
+// segments of equal length and returns the n+1 positions at their ends,
+// starting with a and ending with b. An n below 1 is treated as 1.
+func HaversineWaypoints(a, b polaris.Position, n int) []polaris.Position {
+	return waypoints(a, b, n, func(fraction float64) polaris.Position {
+		return HaversineIntermediate(a, b, fraction)
+	})
+}
+
+// MaxWaypoints is the largest number of positions returned by
+// [HaversineWaypointsEvery] and [VincentyWaypointsEvery].
+const MaxWaypoints = 1 << 20
+
+// HaversineWaypointsEvery returns positions every given number of meters
+// along the great-circle path from a to b on the spherical Earth used by
+// [HaversineDistance], starting with a and ending with b. The last segment is
+// shorter if the distance is not a multiple of meters. For meters ≤ 0 only a
+// and b are returned. It returns an error if the spacing would need more than
+// [MaxWaypoints] positions.
+func HaversineWaypointsEvery(a, b polaris.Position, meters float64) ([]polaris.Position, error) {
+	d := HaversineDistance(a, b)
+	return waypointsEvery(a, b, d, meters, func(fraction float64) polaris.Position {
+		return HaversineIntermediate(a, b, fraction)
+	})
+}
+
+// VincentyIntermediate returns the position at the given fraction of the
+// geodesic from a to b on the WGS-84 ellipsoid: a for 0, b for 1 and the
+// midpoint for 0.5. Fractions outside [0, 1] extend the geodesic beyond a
+// or b.
+//
+// It solves the inverse problem with [VincentyInverse] and then the direct
+// problem with [VincentyDestination]; to place several positions on the same
+// geodesic, use [VincentyWaypoints], which solves the inverse problem once.
+func VincentyIntermediate(a, b polaris.Position, fraction float64) polaris.Position {
+	inv := VincentyInverse(a, b)
+	return vincentyIntermediate(a, b, inv, fraction)
+}
+
+// VincentyMidpoint returns the position halfway along the geodesic from a to b
+// on the WGS-84 ellipsoid.
+func VincentyMidpoint(a, b polaris.Position) polaris.Position {
+	return VincentyIntermediate(a, b, 0.5)
+}
+
+// VincentyWaypoints divides the geodesic from a to b on the WGS-84 ellipsoid
+// into n segments of equal length and returns the n+1 positions at their
+// ends, starting with a and ending with b. An n below 1 is treated as 1.
+func VincentyWaypoints(a, b polaris.Position, n int) []polaris.Position {
+	inv := VincentyInverse(a, b)
+	return waypoints(a, b, n, func(fraction float64) polaris.Position {
+		return vincentyIntermediate(a, b, inv, fraction)
+	})
+}
+
+// VincentyWaypointsEvery returns positions every given number of meters along
+// the geodesic from a to b on the WGS-84 ellipsoid, starting with a and
+// ending with b. The last segment is shorter if the distance is not a
+// multiple of meters. For meters ≤ 0 only a and b are returned. It returns an
+// error if the spacing would need more than [MaxWaypoints] positions.
+func VincentyWaypointsEvery(a, b polaris.Position, meters float64) ([]polaris.Position, error) {
+	inv := VincentyInverse(a, b)
+	return waypointsEvery(a, b, inv.Distance, meters, func(fraction float64) polaris.Position {
+		return vincentyIntermediate(a, b, inv, fraction)
+	})
+}
+
+// vincentyIntermediate returns the position at the given fraction of the
+// geodesic described by inv, which starts at a and ends at b.
+func vincentyIntermediate(a, b polaris.Position, inv Inverse, fraction float64) polaris.Position {
+	switch {
+	case fraction == 0 || inv.Distance == 0:
+		return a
+	case fraction == 1:
+		return b
+	}
+	dest, _ := VincentyDestination(a, inv.InitialBearing, fraction*inv.Distance)
+	return dest
+}
+
+// waypoints returns the positions at the fractions 0, 1/n, ..., 1 given by
+// at, with a and b at the ends.
+func waypoints(a, b polaris.Position, n int, at func(fraction float64) polaris.Position) []polaris.Position {
+	n = max(n, 1)
+	path := make([]polaris.Position, 0, n+1)
+	path = append(path, a)
+	for i := 1; i < n; i++ {
+		path = append(path, at(float64(i)/float64(n)))
+	}
+	return append(path, b)
+}
+
+// waypointsEvery returns the positions every meters along a path of length d
+// from a to b, where at returns the position at a fraction of the path.
+func waypointsEvery(a, b polaris.Position, d, meters float64, at func(fraction float64) polaris.Position) ([]polaris.Position, error) {
+	if !(meters > 0) || d <= meters {
+		return []polaris.Position{a, b}, nil
+	}
+	// Ignore a remainder from rounding errors, which would add a position
+	// right next to b. The segments are counted in floating point first so
+	// that huge counts cannot overflow.
+	segments := math.Ceil(d/meters - 1e-9)
+	if segments+1 > MaxWaypoints {
+		return nil, fmt.Errorf("spacing of %v m over %v m needs more than %d waypoints", meters, d, MaxWaypoints)
+	}
+	n := int(segments)
+	path := make([]polaris.Position, 0, n+1)
+	path = append(path, a)
+	for i := 1; i < n; i++ {
+		path = append(path, at(float64(i)*meters/d))
+	}
+	return append(path, b), nil
+}
@@ -0,0 +1,200 @@
+package distance
+
+import (
+	"math"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/ethz-polymaps/polaris"
+)
+
+func TestHaversineIntermediate(t *testing.T) {
+	tests := []struct {
+		name     string
+		a, b     polaris.Position
+		fraction float64
+		want     polaris.Position
+	}{
+		{
+			name:     "start",
+			a:        polaris.NewPosition(47.3769, 8.5417),
+			b:        polaris.NewPosition(46.9480, 7.4474),
+			fraction: 0,
+			want:     polaris.NewPosition(47.3769, 8.5417),
+		},
+		{
+			name:     "end",
+			a:        polaris.NewPosition(47.3769, 8.5417),
+			b:        polaris.NewPosition(46.9480, 7.4474),
+			fraction: 1,
+			want:     polaris.NewPosition(46.9480, 7.4474),
+		},
+		{
+			name:     "equator",
+			a:        polaris.NewPosition(0, 0),
+			b:        polaris.NewPosition(0, 90),
+			fraction: 1.0 / 3,
+			want:     polaris.NewPosition(0, 30),
+		},
+		{
+			name:     "meridian",
+			a:        polaris.NewPosition(-10, 5),
+			b:        polaris.NewPosition(50, 5),
+			fraction: 0.25,
+			want:     polaris.NewPosition(5, 5),
+		},
+		{
+			name:     "across antimeridian",
+			a:        polaris.NewPosition(0, 170),
+			b:        polaris.NewPosition(0, -160),
+			fraction: 0.5,
+			want:     polaris.NewPosition(0, -175),
+		},
+		{
+			name:     "over the pole",
+			a:        polaris.NewPosition(60, 0),
+			b:        polaris.NewPosition(60, 180),
+			fraction: 0.5,
+			want:     polaris.NewPosition(90, 0),
+		},
+		{
+			name:     "beyond end",
+			a:        polaris.NewPosition(0, 0),
+			b:        polaris.NewPosition(0, 10),
+			fraction: 2,
+			want:     polaris.NewPosition(0, 20),
+		},
+		{
+			name:     "coincident",
+			a:        polaris.NewPosition(47.3769, 8.5417),
+			b:        polaris.NewPosition(47.3769, 8.5417),
+			fraction: 0.5,
+			want:     polaris.NewPosition(47.3769, 8.5417),
+		},
+		{
+			name:     "antipodal",
+			a:        polaris.NewPosition(0, 0),
+			b:        polaris.NewPosition(0, 180),
+			fraction: 0.5,
+			want:     polaris.NewPosition(90, 0),
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := HaversineIntermediate(tt.a, tt.b, tt.fraction)
+			assert.InDelta(t, tt.want.Latitude, got.Latitude, 1e-9)
+			if tt.want.Latitude != 90 {
+				assert.InDelta(t, tt.want.Longitude, got.Longitude, 1e-9)
+			}
+		})
+	}
+}
+
+func TestHaversineMidpoint(t *testing.T) {
+	a := polaris.NewPosition(47.3769, 8.5417)
+	b := polaris.NewPosition(40.7128, -74.0060)
+
+	mid := HaversineMidpoint(a, b)
+	d := HaversineDistance(a, b)
+	assert.InDelta(t, d/2, HaversineDistance(a, mid), 1e-6)
+	assert.InDelta(t, d/2, HaversineDistance(mid, b), 1e-6)
+}
+
+func TestVincentyIntermediate(t *testing.T) {
+	a := polaris.NewPosition(47.3769, 8.5417)
+	b := polaris.NewPosition(-33.8568, 151.2153)
+	d := VincentyDistance(a, b)
+
+	for _, fraction := range []float64{0, 0.1, 0.5, 0.9, 1} {
+		p := VincentyIntermediate(a, b, fraction)
+		assert.InDelta(t, fraction*d, VincentyDistance(a, p), 1e-4, "fraction %v", fraction)
+		assert.InDelta(t, (1-fraction)*d, VincentyDistance(p, b), 1e-4, "fraction %v", fraction)
+	}
+
+	assert.Equal(t, a, VincentyIntermediate(a, a, 0.5))
+
+	mid := VincentyMidpoint(polaris.NewPosition(0, 170), polaris.NewPosition(0, -160))
+	assert.InDelta(t, 0, mid.Latitude, 1e-9)
+	assert.InDelta(t, -175, mid.Longitude, 1e-9)
+}
+
+func TestWaypoints(t *testing.T) {
+	a := polaris.NewPosition(47.3769, 8.5417)
+	b := polaris.NewPosition(46.9480, 7.4474)
+
+	tests := []struct {
+		name         string
+		waypoints    func(a, b polaris.Position, n int) []polaris.Position
+		distanceFunc func(a, b polaris.Position) float64
+	}{
+		{"haversine", HaversineWaypoints, HaversineDistance},
+		{"vincenty", VincentyWaypoints, VincentyDistance},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			path := tt.waypoints(a, b, 4)
+			require.Len(t, path, 5)
+			assert.Equal(t, a, path[0])
+			assert.Equal(t, b, path[4])
+
+			segment := tt.distanceFunc(a, b) / 4
+			for i := 1; i < len(path); i++ {
+				assert.InDelta(t, segment, tt.distanceFunc(path[i-1], path[i]), 1e-6)
+			}
+
+			assert.Equal(t, []polaris.Position{a, b}, tt.waypoints(a, b, 0))
+		})
+	}
+}
+
+func TestWaypointsEvery(t *testing.T) {
+	a := polaris.NewPosition(0, 0)
+	b := polaris.NewPosition(0, 0.01)
+
+	tests := []struct {
+		name         string
+		every        func(a, b polaris.Position, meters float64) ([]polaris.Position, error)
+		distanceFunc func(a, b polaris.Position) float64
+	}{
+		{"haversine", HaversineWaypointsEvery, HaversineDistance},
+		{"vincenty", VincentyWaypointsEvery, VincentyDistance},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			// About 1.11 km, so three segments of 400 m and a shorter one.
+			d := tt.distanceFunc(a, b)
+			require.InDelta(t, 1112, d, 2)
+
+			path, err := tt.every(a, b, 400)
+			require.NoError(t, err)
+			require.Len(t, path, 4)
+			assert.Equal(t, a, path[0])
+			assert.Equal(t, b, path[3])
+			assert.InDelta(t, 400, tt.distanceFunc(path[0], path[1]), 1e-6)
+			assert.InDelta(t, 400, tt.distanceFunc(path[1], path[2]), 1e-6)
+			assert.InDelta(t, d-800, tt.distanceFunc(path[2], path[3]), 1e-6)
+
+			// Exact multiples do not add a position next to b.
+			path, err = tt.every(a, b, d/2)
+			require.NoError(t, err)
+			assert.Len(t, path, 3)
+
+			for _, meters := range []float64{0, -1, 2000} {
+				path, err = tt.every(a, b, meters)
+				require.NoError(t, err)
+				assert.Equal(t, []polaris.Position{a, b}, path)
+			}
+
+			// Tiny spacings are rejected instead of allocating billions of
+			// positions.
+			far := polaris.NewPosition(0, 179)
+			for _, meters := range []float64{1e-6, 1e-300, math.SmallestNonzeroFloat64} {
+				path, err = tt.every(a, far, meters)
+				assert.ErrorContains(t, err, "more than 1048576 waypoints")
+				assert.Nil(t, path)
+			}
+		})
+	}
+}
@@ -0,0 +1,22 @@
+package distance
+
+// Inverse is the solution of the inverse geodesic problem between two
+// positions: the length of the shortest path and the direction of that path
+// at both of its ends. For coincident positions the direction is undefined
+// and both bearings are zero in every model.
+type Inverse struct {
+	// Distance is the length of the path in meters.
+	Distance float64
+	// InitialBearing is the forward azimuth at the start point in degrees
+	// clockwise from north, in the range [0, 360).
+	InitialBearing float64
+	// FinalBearing is the azimuth at the end point in degrees clockwise from
+	// north, in the range [0, 360). It is the direction of travel on arrival.
+	FinalBearing float64
+}
+
+// BackBearing returns the back azimuth at the end point, pointing from the
+// end point towards the start point, in the range [0, 360).
+func (i Inverse) BackBearing() float64 {
+	return normalizeBearing(i.FinalBearing + 180)
+}
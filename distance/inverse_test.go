@@ -0,0 +1,31 @@
+package distance
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/ethz-polymaps/polaris"
+)
+
+func TestInverse_coincidentPoints(t *testing.T) {
+	models := []struct {
+		name    string
+		inverse func(a, b polaris.Position) Inverse
+	}{
+		{"haversine", HaversineInverse},
+		{"vincenty", VincentyInverse},
+		{"karney", KarneyInverse},
+	}
+	for _, m := range models {
+		t.Run(m.name, func(t *testing.T) {
+			for _, p := range []polaris.Position{
+				polaris.NewPosition(47.413310, 8.536444),
+				polaris.NewPosition(0, 0),
+				polaris.NewPosition(-90, 0),
+			} {
+				assert.Equal(t, Inverse{}, m.inverse(p, p), "%v", p)
+			}
+		})
+	}
+}
@@ -0,0 +1,760 @@
+package distance
+
+import (
+	"math"
+
+	"github.com/ethz-polymaps/polaris"
+)
+
+// KarneyDistance calculates the geodesic distance between two points on the
+// WGS-84 ellipsoid using the algorithm of C. F. F. Karney, "Algorithms for
+// geodesics", J. Geodesy 87, 43–55 (2013). Returns the distance in meters.
+//
+// Unlike [VincentyDistance], the method converges for every pair of points,
+// including nearly antipodal ones, and is accurate to about 15 nanometers.
+func KarneyDistance(a, b polaris.Position) float64 {
+	s12, _, _ := wgs84Geodesic.inverse(a.Latitude, a.Longitude, b.Latitude, b.Longitude)
+	return s12
+}
+
+// KarneyInverse solves the inverse geodesic problem on the WGS-84 ellipsoid
+// using Karney's algorithm, returning the distance and both bearings at once.
+// For coincident points the bearings are zero.
+func KarneyInverse(a, b polaris.Position) Inverse {
+	if a.Latitude == b.Latitude && a.Longitude == b.Longitude {
+		return Inverse{}
+	}
+	s12, azi1, azi2 := wgs84Geodesic.inverse(a.Latitude, a.Longitude, b.Latitude, b.Longitude)
+	return Inverse{
+		Distance:       s12,
+		InitialBearing: normalizeBearing(azi1),
+		FinalBearing:   normalizeBearing(azi2),
+	}
+}
+
+// Orders of the series expansions in the third flattening n.
+const (
+	nA1  = 6
+	nC1  = 6
+	nA2  = 6
+	nC2  = 6
+	nA3  = 6
+	nA3x = nA3
+	nC3  = 6
+	nC3x = (nC3 * (nC3 - 1)) / 2
+	nC4  = 6
+	nC4x = (nC4 * (nC4 + 1)) / 2
+)
+
+// Iteration limits and tolerances of the inverse solution.
+const (
+	maxit1 = 20
+	maxit2 = maxit1 + 53 + 10
+)
+
+var (
+	tiny    = math.Sqrt(math.SmallestNonzeroFloat64 * (1 << 52))
+	tol0    = math.Nextafter(1, 2) - 1
+	tol1    = 200 * tol0
+	tol2    = math.Sqrt(tol0)
+	tolb    = tol0 * tol2
+	xthresh = 1000 * tol2
+)
+
+var wgs84Geodesic = newGeodesic(wgs84SemiMajorAxis, 1/wgs84InverseFlattening)
+
+// geodesic holds the ellipsoid-dependent quantities of Karney's algorithm.
+type geodesic struct {
+	a, f, f1, e2, ep2, n, b float64
+	etol2                   float64
+	c2                      float64 // authalic radius squared
+	a3x                     [nA3x]float64
+	c3x                     [nC3x]float64
+	c4x                     [nC4x]float64
+}
+
+// newGeodesic precomputes the series coefficients for the ellipsoid with
+// semi-major axis a and flattening f.
+func newGeodesic(a, f float64) *geodesic {
+	g := &geodesic{a: a, f: f}
+	g.f1 = 1 - f
+	g.e2 = f * (2 - f)
+	g.ep2 = g.e2 / sq(g.f1)
+	g.n = f / (2 - f)
+	g.b = a * g.f1
+	g.etol2 = 0.1 * tol2 / math.Sqrt(math.Max(0.001, math.Abs(f))*math.Min(1, 1-f/2)/2)
+	switch {
+	case g.e2 == 0:
+		g.c2 = sq(a)
+	case g.e2 > 0:
+		g.c2 = (sq(a) + sq(g.b)*math.Atanh(math.Sqrt(g.e2))/math.Sqrt(g.e2)) / 2
+	default:
+		g.c2 = (sq(a) + sq(g.b)*math.Atan(math.Sqrt(-g.e2))/math.Sqrt(-g.e2)) / 2
+	}
+	g.a3coeff()
+	g.c3coeff()
+	g.c4coeff()
+	return g
+}
+
+func (g *geodesic) a3coeff() {
+	coeff := []float64{
+		-3, 128,
+		-2, -3, 64,
+		-1, -3, -1, 16,
+		3, -1, -2, 8,
+		1, -1, 2,
+		1, 1,
+	}
+	o, k := 0, 0
+	for j := nA3 - 1; j >= 0; j-- {
+		m := min(nA3-j-1, j)
+		g.a3x[k] = polyval(m, coeff, o, g.n) / coeff[o+m+1]
+		k++
+		o += m + 2
+	}
+}
+
+func (g *geodesic) c3coeff() {
+	coeff := []float64{
+		3, 128,
+		2, 5, 128,
+		-1, 3, 3, 64,
+		-1, 0, 1, 8,
+		-1, 1, 4,
+		5, 256,
+		1, 3, 128,
+		-3, -2, 3, 64,
+		1, -3, 2, 32,
+		7, 512,
+		-10, 9, 384,
+		5, -9, 5, 192,
+		7, 512,
+		-14, 7, 512,
+		21, 2560,
+	}
+	o, k := 0, 0
+	for l := 1; l < nC3; l++ {
+		for j := nC3 - 1; j >= l; j-- {
+			m := min(nC3-j-1, j)
+			g.c3x[k] = polyval(m, coeff, o, g.n) / coeff[o+m+1]
+			k++
+			o += m + 2
+		}
+	}
+}
+
+func (g *geodesic) c4coeff() {
+	coeff := []float64{
+		97, 15015,
+		1088, 156, 45045,
+		-224, -4784, 1573, 45045,
+		-10656, 14144, -4576, -858, 45045,
+		64, 624, -4576, 6864, -3003, 15015,
+		100, 208, 572, 3432, -12012, 30030, 45045,
+		1, 9009,
+		-2944, 468, 135135,
+		5792, 1040, -1287, 135135,
+		5952, -11648, 9152, -2574, 135135,
+		-64, -624, 4576, -6864, 3003, 135135,
+		8, 10725,
+		1856, -936, 225225,
+		-8448, 4992, -1144, 225225,
+		-1440, 4160, -4576, 1716, 225225,
+		-136, 63063,
+		1024, -208, 105105,
+		3584, -3328, 1144, 315315,
+		-128, 135135,
+		-2560, 832, 405405,
+		128, 99099,
+	}
+	o, k := 0, 0
+	for l := 0; l < nC4; l++ {
+		for j := nC4 - 1; j >= l; j-- {
+			m := nC4 - j - 1
+			g.c4x[k] = polyval(m, coeff, o, g.n) / coeff[o+m+1]
+			k++
+			o += m + 2
+		}
+	}
+}
+
+func (g *geodesic) a3f(eps float64) float64 {
+	return polyval(nA3x-1, g.a3x[:], 0, eps)
+}
+
+func (g *geodesic) c3f(eps float64, c []float64) {
+	mult := 1.0
+	o := 0
+	for l := 1; l < nC3; l++ {
+		m := nC3 - l - 1
+		mult *= eps
+		c[l] = mult * polyval(m, g.c3x[:], o, eps)
+		o += m + 1
+	}
+}
+
+// c4f evaluates the coefficients C4[l] for l = 0..nC4-1 of the area
+// integral.
+func (g *geodesic) c4f(eps float64, c []float64) {
+	mult := 1.0
+	o := 0
+	for l := 0; l < nC4; l++ {
+		m := nC4 - l - 1
+		c[l] = mult * polyval(m, g.c4x[:], o, eps)
+		o += m + 1
+		mult *= eps
+	}
+}
+
+// a1m1f evaluates A1 - 1.
+func a1m1f(eps float64) float64 {
+	coeff := []float64{1, 4, 64, 0, 256}
+	m := nA1 / 2
+	t := polyval(m, coeff, 0, sq(eps)) / coeff[m+1]
+	return (t + eps) / (1 - eps)
+}
+
+// c1f evaluates the coefficients C1[l] for l = 1..nC1.
+func c1f(eps float64, c []float64) {
+	coeff := []float64{
+		-1, 6, -16, 32,
+		-9, 64, -128, 2048,
+		9, -16, 768,
+		3, -5, 512,
+		-7, 1280,
+		-7, 2048,
+	}
+	eps2 := sq(eps)
+	d := eps
+	o := 0
+	for l := 1; l <= nC1; l++ {
+		m := (nC1 - l) / 2
+		c[l] = d * polyval(m, coeff, o, eps2) / coeff[o+m+1]
+		o += m + 2
+		d *= eps
+	}
+}
+
+// a2m1f evaluates A2 - 1.
+func a2m1f(eps float64) float64 {
+	coeff := []float64{-11, -28, -192, 0, 256}
+	m := nA2 / 2
+	t := polyval(m, coeff, 0, sq(eps)) / coeff[m+1]
+	return (t - eps) / (1 + eps)
+}
+
+// c2f evaluates the coefficients C2[l] for l = 1..nC2.
+func c2f(eps float64, c []float64) {
+	coeff := []float64{
+		1, 2, 16, 32,
+		35, 64, 384, 2048,
+		15, 80, 768,
+		7, 35, 512,
+		63, 1280,
+		77, 2048,
+	}
+	eps2 := sq(eps)
+	d := eps
+	o := 0
+	for l := 1; l <= nC2; l++ {
+		m := (nC2 - l) / 2
+		c[l] = d * polyval(m, coeff, o, eps2) / coeff[o+m+1]
+		o += m + 2
+		d *= eps
+	}
+}
+
+// sinCosSeries evaluates a trigonometric series using Clenshaw summation.
+// If sinp is true the sum is Σ c[l] sin(2lx) for l = 1..len(c)-1,
+// otherwise Σ c[l] cos((2l+1)x) for l = 0..len(c)-1.
+func sinCosSeries(sinp bool, sinx, cosx float64, c []float64) float64 {
+	k := len(c)
+	n := k
+	if sinp {
+		n--
+	}
+	ar := 2 * (cosx - sinx) * (cosx + sinx)
+	var y0, y1 float64
+	if n&1 != 0 {
+		k--
+		y0 = c[k]
+	}
+	for n /= 2; n > 0; n-- {
+		k--
+		y1 = ar*y0 - y1 + c[k]
+		k--
+		y0 = ar*y1 - y0 + c[k]
+	}
+	if sinp {
+		return 2 * sinx * cosx * y0
+	}
+	return cosx * (y0 - y1)
+}
+
+// astroid solves k^4 + 2k^3 - (x^2 + y^2 - 1)k^2 - 2y^2 k - y^2 = 0 for the
+// positive root k.
+func astroid(x, y float64) float64 {
+	p := sq(x)
+	q := sq(y)
+	r := (p + q - 1) / 6
+	if q == 0 && r <= 0 {
+		return 0
+	}
+	S := p * q / 4
+	r2 := sq(r)
+	r3 := r * r2
+	disc := S * (S + 2*r3)
+	u := r
+	if disc >= 0 {
+		T3 := S + r3
+		if T3 < 0 {
+			T3 -= math.Sqrt(disc)
+		} else {
+			T3 += math.Sqrt(disc)
+		}
+		T := math.Cbrt(T3)
+		u += T
+		if T != 0 {
+			u += r2 / T
+		}
+	} else {
+		ang := math.Atan2(math.Sqrt(-disc), -(S + r3))
+		u += 2 * r * math.Cos(ang/3)
+	}
+	v := math.Sqrt(sq(u) + q)
+	var uv float64
+	if u < 0 {
+		uv = q / (v - u)
+	} else {
+		uv = u + v
+	}
+	w := (uv - q) / (2 * v)
+	return uv / (math.Sqrt(uv+sq(w)) + w)
+}
+
+// lengths returns the distance s12b and reduced length m12b (both divided by
+// b) together with m0, the coefficient of secular term in m12b.
+func (g *geodesic) lengths(eps, sig12, ssig1, csig1, dn1, ssig2, csig2, dn2 float64,
+	c1a, c2a []float64) (s12b, m12b, m0 float64) {
+	A1 := a1m1f(eps)
+	c1f(eps, c1a)
+	A2 := a2m1f(eps)
+	c2f(eps, c2a)
+	m0 = A1 - A2
+	A1++
+	A2++
+	B1 := sinCosSeries(true, ssig2, csig2, c1a) - sinCosSeries(true, ssig1, csig1, c1a)
+	s12b = A1 * (sig12 + B1)
+	B2 := sinCosSeries(true, ssig2, csig2, c2a) - sinCosSeries(true, ssig1, csig1, c2a)
+	J12 := m0*sig12 + (A1*B1 - A2*B2)
+	m12b = dn2*(csig1*ssig2) - dn1*(ssig1*csig2) - csig1*csig2*J12
+	return s12b, m12b, m0
+}
+
+// inverseStart returns a starting point for Newton's method in salp1 and
+// calp1. If the points are close enough that the solution is obtained
+// directly, sig12 is non-negative and salp2, calp2 and dnm are also set.
+func (g *geodesic) inverseStart(sbet1, cbet1, dn1, sbet2, cbet2, dn2, lam12, slam12, clam12 float64,
+	c1a, c2a []float64) (sig12, salp1, calp1, salp2, calp2, dnm float64) {
+	sig12 = -1
+	salp2, calp2, dnm = math.NaN(), math.NaN(), math.NaN()
+
+	sbet12 := sbet2*cbet1 - cbet2*sbet1
+	cbet12 := cbet2*cbet1 + sbet2*sbet1
+	sbet12a := sbet2*cbet1 + cbet2*sbet1
+
+	shortline := cbet12 >= 0 && sbet12 < 0.5 && cbet2*lam12 < 0.5
+	var somg12, comg12 float64
+	if shortline {
+		sbetm2 := sq(sbet1 + sbet2)
+		sbetm2 /= sbetm2 + sq(cbet1+cbet2)
+		dnm = math.Sqrt(1 + g.ep2*sbetm2)
+		omg12 := lam12 / (g.f1 * dnm)
+		somg12, comg12 = math.Sin(omg12), math.Cos(omg12)
+	} else {
+		somg12, comg12 = slam12, clam12
+	}
+
+	salp1 = cbet2 * somg12
+	if comg12 >= 0 {
+		calp1 = sbet12 + cbet2*sbet1*sq(somg12)/(1+comg12)
+	} else {
+		calp1 = sbet12a - cbet2*sbet1*sq(somg12)/(1-comg12)
+	}
+
+	ssig12 := math.Hypot(salp1, calp1)
+	csig12 := sbet1*sbet2 + cbet1*cbet2*comg12
+
+	switch {
+	case shortline && ssig12 < g.etol2:
+		// Really short lines.
+		salp2 = cbet1 * somg12
+		if comg12 >= 0 {
+			calp2 = sbet12 - cbet1*sbet2*(sq(somg12)/(1+comg12))
+		} else {
+			calp2 = sbet12 - cbet1*sbet2*(1-comg12)
+		}
+		salp2, calp2 = norm(salp2, calp2)
+		sig12 = math.Atan2(ssig12, csig12)
+	case math.Abs(g.n) >= 0.1 || csig12 >= 0 || ssig12 >= 6*math.Abs(g.n)*math.Pi*sq(cbet1):
+		// Nothing to do, the zeroth order spherical approximation is fine.
+	default:
+		// Nearly antipodal points: solve the astroid problem.
+		lam12x := math.Atan2(-slam12, -clam12)
+		var x, y, lamscale, betscale float64
+		if g.f >= 0 {
+			k2 := sq(sbet1) * g.ep2
+			eps := k2 / (2*(1+math.Sqrt(1+k2)) + k2)
+			lamscale = g.f * cbet1 * g.a3f(eps) * math.Pi
+			betscale = lamscale * cbet1
+			x = lam12x / lamscale
+			y = sbet12a / betscale
+		} else {
+			cbet12a := cbet2*cbet1 - sbet2*sbet1
+			bet12a := math.Atan2(sbet12a, cbet12a)
+			_, m12b, m0 := g.lengths(g.n, math.Pi+bet12a, sbet1, -cbet1, dn1, sbet2, cbet2, dn2, c1a, c2a)
+			x = -1 + m12b/(cbet1*cbet2*m0*math.Pi)
+			if x < -0.01 {
+				betscale = sbet12a / x
+			} else {
+				betscale = -g.f * sq(cbet1) * math.Pi
+			}
+			lamscale = betscale / cbet1
+			y = lam12x / lamscale
+		}
+
+		if y > -tol1 && x > -1-xthresh {
+			if g.f >= 0 {
+				salp1 = math.Min(1, -x)
+				calp1 = -math.Sqrt(1 - sq(salp1))
+			} else {
+				calp1 = -1
+				if x > -tol1 {
+					calp1 = 0
+				}
+				calp1 = math.Max(calp1, x)
+				salp1 = math.Sqrt(1 - sq(calp1))
+			}
+		} else {
+			k := astroid(x, y)
+			var omg12a float64
+			if g.f >= 0 {
+				omg12a = lamscale * (-x * k / (1 + k))
+			} else {
+				omg12a = lamscale * (-y * (1 + k) / k)
+			}
+			somg12, comg12 = math.Sin(omg12a), -math.Cos(omg12a)
+			salp1 = cbet2 * somg12
+			calp1 = sbet12a - cbet2*sbet1*sq(somg12)/(1-comg12)
+		}
+	}
+
+	if !(salp1 <= 0) {
+		salp1, calp1 = norm(salp1, calp1)
+	} else {
+		salp1, calp1 = 1, 0
+	}
+	return sig12, salp1, calp1, salp2, calp2, dnm
+}
+
+// lambda12 evaluates the longitude difference for the azimuth (salp1, calp1)
+// relative to the target (slam120, clam120), and its derivative if diffp.
+func (g *geodesic) lambda12(sbet1, cbet1, dn1, sbet2, cbet2, dn2, salp1, calp1, slam120, clam120 float64,
+	diffp bool, c1a, c2a, c3a []float64) (lam12, salp2, calp2, sig12, ssig1, csig1, ssig2, csig2, eps, domg12, dlam12 float64) {
+	if sbet1 == 0 && calp1 == 0 {
+		// Break degeneracy of equatorial line.
+		calp1 = -tiny
+	}
+
+	salp0 := salp1 * cbet1
+	calp0 := math.Hypot(calp1, salp1*sbet1)
+
+	ssig1 = sbet1
+	somg1 := salp0 * sbet1
+	csig1 = calp1 * cbet1
+	comg1 := csig1
+	ssig1, csig1 = norm(ssig1, csig1)
+
+	if cbet2 != cbet1 {
+		salp2 = salp0 / cbet2
+	} else {
+		salp2 = salp1
+	}
+	if cbet2 != cbet1 || math.Abs(sbet2) != -sbet1 {
+		var t float64
+		if cbet1 < -sbet1 {
+			t = (cbet2 - cbet1) * (cbet1 + cbet2)
+		} else {
+			t = (sbet1 - sbet2) * (sbet1 + sbet2)
+		}
+		calp2 = math.Sqrt(sq(calp1*cbet1)+t) / cbet2
+	} else {
+		calp2 = math.Abs(calp1)
+	}
+
+	ssig2 = sbet2
+	somg2 := salp0 * sbet2
+	csig2 = calp2 * cbet2
+	comg2 := csig2
+	ssig2, csig2 = norm(ssig2, csig2)
+
+	sig12 = math.Atan2(math.Max(0, csig1*ssig2-ssig1*csig2), csig1*csig2+ssig1*ssig2)
+	somg12 := math.Max(0, comg1*somg2-somg1*comg2)
+	comg12 := comg1*comg2 + somg1*somg2
+	eta := math.Atan2(somg12*clam120-comg12*slam120, comg12*clam120+somg12*slam120)
+
+	k2 := sq(calp0) * g.ep2
+	eps = k2 / (2*(1+math.Sqrt(1+k2)) + k2)
+	g.c3f(eps, c3a)
+	B312 := sinCosSeries(true, ssig2, csig2, c3a) - sinCosSeries(true, ssig1, csig1, c3a)
+	domg12 = -g.f * g.a3f(eps) * salp0 * (sig12 + B312)
+	lam12 = eta + domg12
+
+	if diffp {
+		if calp2 == 0 {
+			dlam12 = -2 * g.f1 * dn1 / sbet1
+		} else {
+			_, dlam12, _ = g.lengths(eps, sig12, ssig1, csig1, dn1, ssig2, csig2, dn2, c1a, c2a)
+			dlam12 *= g.f1 / (calp2 * cbet2)
+		}
+	} else {
+		dlam12 = math.NaN()
+	}
+	return lam12, salp2, calp2, sig12, ssig1, csig1, ssig2, csig2, eps, domg12, dlam12
+}
+
+// inverse solves the inverse geodesic problem between (lat1, lon1) and
+// (lat2, lon2), given in degrees. It returns the distance s12 in meters and
+// the azimuths azi1 and azi2 in degrees, measured clockwise from north at the
+// first and second point respectively.
+func (g *geodesic) inverse(lat1, lon1, lat2, lon2 float64) (s12, azi1, azi2 float64) {
+	s12, azi1, azi2, _ = g.genInverse(lat1, lon1, lat2, lon2, false)
+	return s12, azi1, azi2
+}
+
+// genInverse is [geodesic.inverse] that also returns, if area is set, the
+// area S12 in square meters between the geodesic and the equator, counted
+// positive when the geodesic runs counterclockwise around that area.
+func (g *geodesic) genInverse(lat1, lon1, lat2, lon2 float64, area bool) (s12, azi1, azi2, S12 float64) {
+	lon12, lon12s := angDiff(lon1, lon2)
+	// Make longitude difference positive.
+	lonsign := math.Copysign(1, lon12)
+	lon12 = lonsign * angRound(lon12)
+	lon12s = angRound((180 - lon12) - lonsign*lon12s)
+	lam12 := lon12 * math.Pi / 180
+	var slam12, clam12 float64
+	if lon12 > 90 {
+		slam12, clam12 = sincosd(lon12s)
+		clam12 = -clam12
+	} else {
+		slam12, clam12 = sincosd(lon12)
+	}
+
+	lat1 = angRound(latFix(lat1))
+	lat2 = angRound(latFix(lat2))
+	// Swap points so that the point with the larger latitude magnitude is first.
+	swapp := 1.0
+	if math.Abs(lat1) < math.Abs(lat2) || math.IsNaN(lat2) {
+		swapp = -1
+		lonsign *= -1
+		lat1, lat2 = lat2, lat1
+	}
+	// Make lat1 <= -0.
+	latsign := math.Copysign(1, -lat1)
+	lat1 *= latsign
+	lat2 *= latsign
+
+	sbet1, cbet1 := sincosd(lat1)
+	sbet1 *= g.f1
+	sbet1, cbet1 = norm(sbet1, cbet1)
+	cbet1 = math.Max(tiny, cbet1)
+
+	sbet2, cbet2 := sincosd(lat2)
+	sbet2 *= g.f1
+	sbet2, cbet2 = norm(sbet2, cbet2)
+	cbet2 = math.Max(tiny, cbet2)
+
+	// Ensure that the points are treated symmetrically when |bet1| == |bet2|.
+	if cbet1 < -sbet1 {
+		if cbet2 == cbet1 {
+			sbet2 = math.Copysign(sbet1, sbet2)
+		}
+	} else if math.Abs(sbet2) == -sbet1 {
+		cbet2 = cbet1
+	}
+
+	dn1 := math.Sqrt(1 + g.ep2*sq(sbet1))
+	dn2 := math.Sqrt(1 + g.ep2*sq(sbet2))
+
+	var c1a [nC1 + 1]float64
+	var c2a [nC2 + 1]float64
+	var c3a [nC3]float64
+
+	var salp1, calp1, salp2, calp2, s12x float64
+	// somg12 > 1 marks that omg12 still has to be converted to its sine
+	// and cosine for the area.
+	omg12, somg12, comg12 := 0.0, 2.0, 0.0
+
+	meridian := lat1 == -90 || slam12 == 0
+	if meridian {
+		// Endpoints are on a single full meridian.
+		calp1, salp1 = clam12, slam12
+		calp2, salp2 = 1, 0
+		ssig1, csig1 := sbet1, calp1*cbet1
+		ssig2, csig2 := sbet2, calp2*cbet2
+		sig12 := math.Atan2(math.Max(0, csig1*ssig2-ssig1*csig2), csig1*csig2+ssig1*ssig2)
+		s12b, m12b, _ := g.lengths(g.n, sig12, ssig1, csig1, dn1, ssig2, csig2, dn2, c1a[:], c2a[:])
+		// The shortest path is along the meridian unless m12 < 0.
+		if sig12 < 1 || m12b >= 0 {
+			if sig12 < 3*tiny || (sig12 < tol0 && (s12b < 0 || m12b < 0)) {
+				s12b = 0
+			}
+			s12x = s12b * g.b
+		} else {
+			meridian = false
+		}
+	}
+
+	if !meridian && sbet1 == 0 && (g.f <= 0 || lon12s >= g.f*180) {
+		// Geodesic runs along the equator.
+		calp1, calp2 = 0, 0
+		salp1, salp2 = 1, 1
+		s12x = g.a * lam12
+		omg12 = lam12 / g.f1
+	} else if !meridian {
+		sig12, sa1, ca1, sa2, ca2, dnm := g.inverseStart(sbet1, cbet1, dn1, sbet2, cbet2, dn2,
+			lam12, slam12, clam12, c1a[:], c2a[:])
+		salp1, calp1 = sa1, ca1
+		if sig12 >= 0 {
+			// Short lines, the starting point is the solution.
+			salp2, calp2 = sa2, ca2
+			s12x = sig12 * g.b * dnm
+			omg12 = lam12 / (g.f1 * dnm)
+		} else {
+			// Newton's method, falling back to bisection on a bracket.
+			var ssig1, csig1, ssig2, csig2, eps, domg12 float64
+			numit := 0
+			tripn, tripb := false, false
+			salp1a, calp1a := tiny, 1.0
+			salp1b, calp1b := tiny, -1.0
+			for ; ; numit++ {
+				var v, dv float64
+				v, salp2, calp2, sig12, ssig1, csig1, ssig2, csig2, eps, domg12, dv = g.lambda12(
+					sbet1, cbet1, dn1, sbet2, cbet2, dn2, salp1, calp1, slam12, clam12,
+					numit < maxit1, c1a[:], c2a[:], c3a[:])
+				tol := tol0
+				if tripn {
+					tol *= 8
+				}
+				if tripb || !(math.Abs(v) >= tol) || numit == maxit2 {
+					break
+				}
+				// Update bracketing values.
+				if v > 0 && (numit > maxit1 || calp1/salp1 > calp1b/salp1b) {
+					salp1b, calp1b = salp1, calp1
+				} else if v < 0 && (numit > maxit1 || calp1/salp1 < calp1a/salp1a) {
+					salp1a, calp1a = salp1, calp1
+				}
+				if numit < maxit1 && dv > 0 {
+					dalp1 := -v / dv
+					if math.Abs(dalp1) < math.Pi {
+						sdalp1, cdalp1 := math.Sin(dalp1), math.Cos(dalp1)
+						nsalp1 := salp1*cdalp1 + calp1*sdalp1
+						if nsalp1 > 0 {
+							calp1 = calp1*cdalp1 - salp1*sdalp1
+							salp1 = nsalp1
+							salp1, calp1 = norm(salp1, calp1)
+							tripn = math.Abs(v) <= 16*tol0
+							continue
+						}
+					}
+				}
+				// Newton failed, bisect the bracket instead.
+				salp1 = (salp1a + salp1b) / 2
+				calp1 = (calp1a + calp1b) / 2
+				salp1, calp1 = norm(salp1, calp1)
+				tripn = false
+				tripb = math.Abs(salp1a-salp1)+(calp1a-calp1) < tolb ||
+					math.Abs(salp1-salp1b)+(calp1-calp1b) < tolb
+			}
+			s12b, _, _ := g.lengths(eps, sig12, ssig1, csig1, dn1, ssig2, csig2, dn2, c1a[:], c2a[:])
+			s12x = s12b * g.b
+			if area {
+				// omg12 = lam12 - domg12
+				sdomg12, cdomg12 := math.Sin(domg12), math.Cos(domg12)
+				somg12 = slam12*cdomg12 - clam12*sdomg12
+				comg12 = clam12*cdomg12 + slam12*sdomg12
+			}
+		}
+	}
+
+	s12 = 0 + s12x
+
+	if area {
+		S12 = g.area(sbet1, cbet1, sbet2, cbet2, salp1, calp1, salp2, calp2, omg12, somg12, comg12, meridian)
+		S12 *= swapp * lonsign * latsign
+		// Convert -0 to 0.
+		S12 += 0
+	}
+
+	if swapp < 0 {
+		salp1, salp2 = salp2, salp1
+		calp1, calp2 = calp2, calp1
+	}
+	salp1 *= swapp * lonsign
+	calp1 *= swapp * latsign
+	salp2 *= swapp * lonsign
+	calp2 *= swapp * latsign
+
+	return s12, atan2d(salp1, calp1), atan2d(salp2, calp2), S12
+}
+
+// area returns the area between the geodesic and the equator for the reduced
+// latitudes and azimuths of the canonical problem solved by genInverse.
+func (g *geodesic) area(sbet1, cbet1, sbet2, cbet2, salp1, calp1, salp2, calp2, omg12, somg12, comg12 float64, meridian bool) float64 {
+	var S12 float64
+	salp0 := salp1 * cbet1
+	calp0 := math.Hypot(calp1, salp1*sbet1)
+	if calp0 != 0 && salp0 != 0 {
+		ssig1, csig1 := norm(sbet1, calp1*cbet1)
+		ssig2, csig2 := norm(sbet2, calp2*cbet2)
+		k2 := sq(calp0) * g.ep2
+		eps := k2 / (2*(1+math.Sqrt(1+k2)) + k2)
+		// Multiplier a^2 e^2 cos(alpha0) sin(alpha0).
+		A4 := sq(g.a) * calp0 * salp0 * g.e2
+		var c4a [nC4]float64
+		g.c4f(eps, c4a[:])
+		B41 := sinCosSeries(false, ssig1, csig1, c4a[:])
+		B42 := sinCosSeries(false, ssig2, csig2, c4a[:])
+		S12 = A4 * (B42 - B41)
+	}
+	// Otherwise the geodesic runs along the equator or a meridian, where sig1
+	// and sig2 are indeterminate and the integral vanishes.
+
+	if !meridian && somg12 > 1 {
+		somg12, comg12 = math.Sin(omg12), math.Cos(omg12)
+	}
+
+	var alp12 float64
+	if !meridian && comg12 > -0.7071 && sbet2-sbet1 < 1.75 {
+		// Use tan(Gamma/2) = tan(omg12/2) *
+		// (tan(bet1/2) + tan(bet2/2)) / (1 + tan(bet1/2) tan(bet2/2))
+		// with tan(x/2) = sin(x) / (1 + cos(x)).
+		domg12 := 1 + comg12
+		dbet1 := 1 + cbet1
+		dbet2 := 1 + cbet2
+		alp12 = 2 * math.Atan2(somg12*(sbet1*dbet2+sbet2*dbet1), domg12*(sbet1*sbet2+dbet1*dbet2))
+	} else {
+		// alp12 = alp2 - alp1, used in atan2 so no need to normalize.
+		salp12 := salp2*calp1 - calp2*salp1
+		calp12 := calp2*calp1 + salp2*salp1
+		// Make alp12 = -180 rather than 180 when alp1 = ±180 and alp2 = 0.
+		if salp12 == 0 && calp12 < 0 {
+			salp12 = tiny * calp1
+			calp12 = -1
+		}
+		alp12 = math.Atan2(salp12, calp12)
+	}
+	return S12 + g.c2*alp12
+}
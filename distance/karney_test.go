@@ -0,0 +1,212 @@
+package distance
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/ethz-polymaps/polaris"
+)
+
+func TestKarneyDistance(t *testing.T) {
+	type args struct {
+		a polaris.Position
+		b polaris.Position
+	}
+	tests := []struct {
+		name  string
+		args  args
+		want  float64
+		delta float64
+	}{
+		{
+			name: "same point",
+			args: args{
+				a: polaris.NewPosition(47.413310, 8.536444),
+				b: polaris.NewPosition(47.413310, 8.536444),
+			},
+			want:  0,
+			delta: 0,
+		},
+		{
+			name: "short distance ~5.7m",
+			args: args{
+				a: polaris.NewPosition(47.413310, 8.536444),
+				b: polaris.NewPosition(47.413309, 8.536520),
+			},
+			want:  5.7366,
+			delta: 0.001,
+		},
+		{
+			name: "long distance ~383km",
+			args: args{
+				b: polaris.NewPosition(39.099912, -94.581213),
+				a: polaris.NewPosition(38.627089, -90.200203),
+			},
+			want:  383805.76,
+			delta: 1,
+		},
+		// Reference cases from the GeographicLib test suite (GeodTest.dat).
+		{
+			name: "geodtest 1",
+			args: args{
+				a: polaris.NewPosition(35.60777, -139.44815),
+				b: polaris.NewPosition(-11.17491, -69.95921),
+			},
+			want:  8935244.5604818305,
+			delta: 1e-8,
+		},
+		{
+			name: "geodtest 2",
+			args: args{
+				a: polaris.NewPosition(55.52454, 106.05087),
+				b: polaris.NewPosition(77.03196, 197.18234),
+			},
+			want:  4105086.1713924406,
+			delta: 1e-8,
+		},
+		{
+			name: "geodtest 3",
+			args: args{
+				a: polaris.NewPosition(-21.97856, 142.59065),
+				b: polaris.NewPosition(41.84138, 98.56635),
+			},
+			want:  8394328.894657671,
+			delta: 1e-8,
+		},
+		{
+			name: "geodtest 4",
+			args: args{
+				a: polaris.NewPosition(-66.99028, 112.2363),
+				b: polaris.NewPosition(-12.70631, 285.90344),
+			},
+			want:  11150344.2312080241,
+			delta: 1e-8,
+		},
+		{
+			name: "geodtest 5",
+			args: args{
+				a: polaris.NewPosition(-17.42761, 173.34268),
+				b: polaris.NewPosition(-15.84784, 5.93557),
+			},
+			want:  16076603.1631180673,
+			delta: 1e-8,
+		},
+		{
+			name: "geodtest 6",
+			args: args{
+				a: polaris.NewPosition(-87.85331, 85.66836),
+				b: polaris.NewPosition(66.48646, 16.09921),
+			},
+			want:  17286615.3147144645,
+			delta: 1e-8,
+		},
+		{
+			name: "nearly antipodal wellington to salamanca",
+			args: args{
+				a: polaris.NewPosition(-41.32, 174.81),
+				b: polaris.NewPosition(40.96, -5.50),
+			},
+			want:  19959679.26735382,
+			delta: 1e-8,
+		},
+		{
+			name: "antipodal on equator runs over the pole",
+			args: args{
+				a: polaris.NewPosition(0, 0),
+				b: polaris.NewPosition(0, 180),
+			},
+			want:  20003931.458625,
+			delta: 1e-6,
+		},
+		{
+			name: "along the equator",
+			args: args{
+				a: polaris.NewPosition(0, 0),
+				b: polaris.NewPosition(0, 90),
+			},
+			want:  10018754.171394,
+			delta: 1e-6,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			result := KarneyDistance(tt.args.a, tt.args.b)
+			assert.InDelta(t, tt.want, result, tt.delta)
+
+			reverse := KarneyDistance(tt.args.b, tt.args.a)
+			assert.InDelta(t, tt.want, reverse, tt.delta)
+		})
+	}
+}
+
+func TestKarneyDistance_nearlyAntipodalConverges(t *testing.T) {
+	// Vincenty's iteration does not converge for these points and is off by
+	// several kilometers. Karney's result must be stable under perturbation.
+	a := polaris.NewPosition(0, 0)
+	b := polaris.NewPosition(0.5, 179.7)
+
+	result := KarneyDistance(a, b)
+	perturbed := KarneyDistance(a, polaris.NewPosition(0.5, 179.7+1e-9))
+	assert.InDelta(t, perturbed, result, 1e-3)
+	assert.Greater(t, result, 19900000.0)
+	assert.Less(t, result, 20003931.458625)
+}
+
+func TestKarneyInverse(t *testing.T) {
+	type args struct {
+		a polaris.Position
+		b polaris.Position
+	}
+	// Reference cases from the GeographicLib test suite (GeodTest.dat).
+	tests := []struct {
+		name string
+		args args
+		want Inverse
+	}{
+		{
+			name: "geodtest 1",
+			args: args{
+				a: polaris.NewPosition(35.60777, -139.44815),
+				b: polaris.NewPosition(-11.17491, -69.95921),
+			},
+			want: Inverse{Distance: 8935244.5604818305, InitialBearing: 111.098748429560326, FinalBearing: 129.289270889708762},
+		},
+		{
+			name: "geodtest 3",
+			args: args{
+				a: polaris.NewPosition(-21.97856, 142.59065),
+				b: polaris.NewPosition(41.84138, 98.56635),
+			},
+			want: Inverse{Distance: 8394328.894657671, InitialBearing: 360 - 32.44456876433189, FinalBearing: 360 - 41.84359951440466},
+		},
+		{
+			name: "geodtest 5",
+			args: args{
+				a: polaris.NewPosition(-17.42761, 173.34268),
+				b: polaris.NewPosition(-15.84784, 5.93557),
+			},
+			want: Inverse{Distance: 16076603.1631180673, InitialBearing: 360 - 159.033557661192928, FinalBearing: 360 - 20.787484651536988},
+		},
+		{
+			name: "nearly antipodal wellington to salamanca",
+			args: args{
+				a: polaris.NewPosition(-41.32, 174.81),
+				b: polaris.NewPosition(40.96, -5.50),
+			},
+			want: Inverse{Distance: 19959679.26735382, InitialBearing: 161.06766998615, FinalBearing: 18.825195123248},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			result := KarneyInverse(tt.args.a, tt.args.b)
+			assert.InDelta(t, tt.want.Distance, result.Distance, 1e-8)
+			assert.InDelta(t, tt.want.InitialBearing, result.InitialBearing, 1e-10)
+			assert.InDelta(t, tt.want.FinalBearing, result.FinalBearing, 1e-10)
+
+			// The back bearing from b points to a, the reverse geodesic's initial bearing.
+			reverse := KarneyInverse(tt.args.b, tt.args.a)
+			assert.InDelta(t, result.BackBearing(), reverse.InitialBearing, 1e-10)
+		})
+	}
+}
@@ -0,0 +1,128 @@
+package distance
+
+import (
+	"context"
+	"errors"
+	"runtime"
+	"sync"
+	"sync/atomic"
+
+	"gonum.org/v1/gonum/mat"
+
+	"github.com/ethz-polymaps/polaris"
+)
+
+// MatrixOpt is a functional option for configuring [Matrix] and
+// [SymmetricMatrix].
+type MatrixOpt func(*MatrixConfig)
+
+// MatrixConfig holds the configuration of a distance matrix computation.
+type MatrixConfig struct {
+	// Workers is the number of goroutines computing rows in parallel.
+	// Defaults to runtime.GOMAXPROCS(0).
+	Workers int
+}
+
+// Matrix computes the distances in meters from every position in from to
+// every position in to with the given distance function, such as
+// [HaversineDistance] or the Distance method of a [Ruler]. Element (i, j) of
+// the result, which is stored in row-major order, is distanceFunc(from[i],
+// to[j]).
+//
+// Rows are distributed over a pool of workers, so distanceFunc must be safe
+// for concurrent use; all functions of this package are. If ctx is canceled
+// before the computation is done, Matrix stops and returns the context's
+// error. It also returns an error if from or to is empty.
+func Matrix(ctx context.Context, from, to []polaris.Position, distanceFunc func(a, b polaris.Position) float64, opts ...MatrixOpt) (*mat.Dense, error) {
+	if len(from) == 0 || len(to) == 0 {
+		return nil, errors.New("distance matrix needs at least one position in from and to")
+	}
+
+	m := mat.NewDense(len(from), len(to), nil)
+	raw := m.RawMatrix()
+	err := forEachRow(ctx, len(from), newMatrixConfig(opts), func(i int) {
+		row := raw.Data[i*raw.Stride : i*raw.Stride+len(to)]
+		for j, b := range to {
+			row[j] = distanceFunc(from[i], b)
+		}
+	})
+	if err != nil {
+		return nil, err
+	}
+	return m, nil
+}
+
+// SymmetricMatrix computes the distances in meters between all pairs of the
+// given positions like [Matrix], but evaluates distanceFunc only for the
+// pairs of the upper triangle and leaves the diagonal at zero. This halves
+// the work for symmetric distance functions, which all functions of this
+// package are.
+func SymmetricMatrix(ctx context.Context, positions []polaris.Position, distanceFunc func(a, b polaris.Position) float64, opts ...MatrixOpt) (*mat.SymDense, error) {
+	n := len(positions)
+	if n == 0 {
+		return nil, errors.New("distance matrix needs at least one position")
+	}
+
+	m := mat.NewSymDense(n, nil)
+	raw := m.RawSymmetric()
+	err := forEachRow(ctx, n, newMatrixConfig(opts), func(i int) {
+		// The upper triangle of row i starts at its diagonal element.
+		row := raw.Data[i*raw.Stride : i*raw.Stride+n]
+		for j := i + 1; j < n; j++ {
+			row[j] = distanceFunc(positions[i], positions[j])
+		}
+	})
+	if err != nil {
+		return nil, err
+	}
+	return m, nil
+}
+
+// newMatrixConfig applies the options to the default configuration.
+func newMatrixConfig(opts []MatrixOpt) *MatrixConfig {
+	config := &MatrixConfig{Workers: runtime.GOMAXPROCS(0)}
+	for _, opt := range opts {
+		opt(config)
+	}
+	if config.Workers < 1 {
+		config.Workers = 1
+	}
+	return config
+}
+
+// forEachRow calls computeRow for the rows 0 to n-1 on the configured number
+// of workers. Once ctx is canceled, the remaining rows are skipped and the
+// context's error is returned. A cancellation after the last row has started
+// does not discard the result.
+func forEachRow(ctx context.Context, n int, config *MatrixConfig, computeRow func(i int)) error {
+	rows := make(chan int)
+	var skipped atomic.Bool
+	var wg sync.WaitGroup
+	for range min(config.Workers, n) {
+		wg.Go(func() {
+			for i := range rows {
+				if ctx.Err() != nil {
+					skipped.Store(true)
+					continue
+				}
+				computeRow(i)
+			}
+		})
+	}
+
+feed:
+	for i := range n {
+		select {
+		case rows <- i:
+		case <-ctx.Done():
+			skipped.Store(true)
+			break feed
+		}
+	}
+	close(rows)
+	wg.Wait()
+	if skipped.Load() {
+		return ctx.Err()
+	}
+	return nil
+}
@@ -0,0 +1,11 @@
+package distance
+
+// WithWorkers sets the number of goroutines that compute the rows of a
+// distance matrix in parallel. Values below 1 are treated as 1:
+//
+//	m, err := distance.Matrix(ctx, anchors, tags, distance.VincentyDistance, distance.WithWorkers(4))
+func WithWorkers(workers int) MatrixOpt {
+	return func(c *MatrixConfig) {
+		c.Workers = workers
+	}
+}
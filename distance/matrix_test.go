@@ -0,0 +1,152 @@
+package distance
+
+import (
+	"context"
+	"sync/atomic"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/ethz-polymaps/polaris"
+)
+
+var matrixPositions = []polaris.Position{
+	polaris.NewPosition(47.3769, 8.5417),
+	polaris.NewPosition(46.9480, 7.4474),
+	polaris.NewPosition(46.2044, 6.1432),
+	polaris.NewPosition(47.5596, 7.5886),
+	polaris.NewPosition(46.0037, 8.9511),
+}
+
+func TestMatrix(t *testing.T) {
+	from := matrixPositions[:2]
+	to := matrixPositions
+
+	for _, workers := range []int{0, 1, 3, 16} {
+		m, err := Matrix(context.Background(), from, to, VincentyDistance, WithWorkers(workers))
+		require.NoError(t, err)
+
+		rows, cols := m.Dims()
+		require.Equal(t, len(from), rows)
+		require.Equal(t, len(to), cols)
+		for i, a := range from {
+			for j, b := range to {
+				assert.Equal(t, VincentyDistance(a, b), m.At(i, j), "workers %d, (%d, %d)", workers, i, j)
+			}
+		}
+	}
+}
+
+func TestSymmetricMatrix(t *testing.T) {
+	var calls atomic.Int64
+	countingDistance := func(a, b polaris.Position) float64 {
+		calls.Add(1)
+		return HaversineDistance(a, b)
+	}
+
+	m, err := SymmetricMatrix(context.Background(), matrixPositions, countingDistance, WithWorkers(2))
+	require.NoError(t, err)
+
+	n := len(matrixPositions)
+	assert.Equal(t, int64(n*(n-1)/2), calls.Load())
+	assert.Equal(t, n, m.SymmetricDim())
+
+	full, err := Matrix(context.Background(), matrixPositions, matrixPositions, HaversineDistance)
+	require.NoError(t, err)
+	for i := range n {
+		for j := range n {
+			assert.InDelta(t, full.At(i, j), m.At(i, j), 1e-9, "(%d, %d)", i, j)
+		}
+	}
+}
+
+func TestMatrix_canceled(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+
+	_, err := Matrix(ctx, matrixPositions, matrixPositions, HaversineDistance)
+	assert.ErrorIs(t, err, context.Canceled)
+
+	_, err = SymmetricMatrix(ctx, matrixPositions, HaversineDistance)
+	assert.ErrorIs(t, err, context.Canceled)
+}
+
+func TestMatrix_canceledWhileRunning(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	var calls atomic.Int64
+	cancelingDistance := func(a, b polaris.Position) float64 {
+		if calls.Add(1) == 3 {
+			cancel()
+		}
+		return HaversineDistance(a, b)
+	}
+
+	_, err := Matrix(ctx, matrixPositions, matrixPositions, cancelingDistance, WithWorkers(1))
+	assert.ErrorIs(t, err, context.Canceled)
+	assert.Less(t, calls.Load(), int64(len(matrixPositions)*len(matrixPositions)))
+}
+
+func TestMatrix_canceledAfterLastRow(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	// The context is canceled while the last row is computed, so no row is
+	// skipped and the matrix is complete.
+	n := int64(len(matrixPositions) * len(matrixPositions))
+	var calls atomic.Int64
+	cancelingDistance := func(a, b polaris.Position) float64 {
+		if calls.Add(1) == n {
+			cancel()
+		}
+		return HaversineDistance(a, b)
+	}
+
+	m, err := Matrix(ctx, matrixPositions, matrixPositions, cancelingDistance, WithWorkers(1))
+	require.NoError(t, err)
+	assert.Equal(t, n, calls.Load())
+	assert.InDelta(t, HaversineDistance(matrixPositions[1], matrixPositions[2]), m.At(1, 2), 1e-9)
+}
+
+func TestMatrix_empty(t *testing.T) {
+	_, err := Matrix(context.Background(), nil, matrixPositions, HaversineDistance)
+	assert.EqualError(t, err, "distance matrix needs at least one position in from and to")
+
+	_, err = Matrix(context.Background(), matrixPositions, nil, HaversineDistance)
+	assert.Error(t, err)
+
+	_, err = SymmetricMatrix(context.Background(), nil, HaversineDistance)
+	assert.EqualError(t, err, "distance matrix needs at least one position")
+}
+
+func BenchmarkMatrix(b *testing.B) {
+	positions := make([]polaris.Position, 200)
+	for i := range positions {
+		positions[i] = polaris.NewPosition(47+float64(i%20)*0.01, 8+float64(i/20)*0.01)
+	}
+	ctx := context.Background()
+
+	b.Run("full", func(b *testing.B) {
+		for b.Loop() {
+			if _, err := Matrix(ctx, positions, positions, VincentyDistance); err != nil {
+				b.Fatal(err)
+			}
+		}
+	})
+	b.Run("symmetric", func(b *testing.B) {
+		for b.Loop() {
+			if _, err := SymmetricMatrix(ctx, positions, VincentyDistance); err != nil {
+				b.Fatal(err)
+			}
+		}
+	})
+	b.Run("single worker", func(b *testing.B) {
+		for b.Loop() {
+			if _, err := Matrix(ctx, positions, positions, VincentyDistance, WithWorkers(1)); err != nil {
+				b.Fatal(err)
+			}
+		}
+	})
+}
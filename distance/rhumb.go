@@ -0,0 +1,94 @@
+package distance
+
+import (
+	"math"
+
+	"github.com/ethz-polymaps/polaris"
+)
+
+// RhumbDistance returns the length in meters of the rhumb line, or loxodrome,
+// from a to b on the spherical Earth used by [HaversineDistance]. A rhumb line
+// crosses every meridian at the same angle, so it is the path travelled at a
+// constant compass heading. It is never shorter than the great circle and
+// takes the shorter way around the antimeridian.
+//
+// The function can be passed to trilateration.WithDistanceFunc.
+func RhumbDistance(a, b polaris.Position) float64 {
+	lat1 := a.Latitude * math.Pi / 180
+	lat2 := b.Latitude * math.Pi / 180
+	dLat := lat2 - lat1
+	dLon := angNormalize(b.Longitude-a.Longitude) * math.Pi / 180
+
+	// On an east-west line the stretched latitude difference vanishes and
+	// the ratio of latitude differences tends to cos(lat).
+	q := math.Cos(lat1)
+	if dPsi := stretchedLatitudeDifference(lat1, lat2); math.Abs(dPsi) > 1e-12 {
+		q = dLat / dPsi
+	}
+	return math.Hypot(dLat, q*dLon) * earthRadius
+}
+
+// RhumbBearing returns the constant bearing of the rhumb line from a to b in
+// degrees clockwise from north in the range [0, 360). From a pole the bearing
+// is 180 or 0, and for coincident points it is 0.
+func RhumbBearing(a, b polaris.Position) float64 {
+	lat1 := a.Latitude * math.Pi / 180
+	lat2 := b.Latitude * math.Pi / 180
+	dLon := angNormalize(b.Longitude-a.Longitude) * math.Pi / 180
+	dPsi := stretchedLatitudeDifference(lat1, lat2)
+	switch {
+	case math.IsNaN(dPsi):
+		// Both positions lie on the same pole.
+		return 0
+	case math.IsInf(dPsi, 0):
+		// Lines to and from a pole run along a meridian.
+		dLon = 0
+	}
+	return normalizeBearing(math.Atan2(dLon, dPsi) * 180 / math.Pi)
+}
+
+// RhumbDestination returns the position reached by travelling the given
+// distance in meters from start at a constant bearing in degrees clockwise
+// from north, on the spherical Earth used by [HaversineDistance].
+//
+// A rhumb line that is not a parallel spirals into a pole after a finite
+// distance. Travelling further ends at the pole.
+func RhumbDestination(start polaris.Position, bearing, meters float64) polaris.Position {
+	lat1 := start.Latitude * math.Pi / 180
+	theta := bearing * math.Pi / 180
+	delta := meters / earthRadius
+
+	dLat := delta * math.Cos(theta)
+	lat2 := lat1 + dLat
+	if math.Abs(lat2) >= math.Pi/2 {
+		return polaris.NewPosition(math.Copysign(90, lat2), start.Longitude)
+	}
+
+	q := math.Cos(lat1)
+	if dPsi := stretchedLatitudeDifference(lat1, lat2); math.Abs(dPsi) > 1e-12 {
+		q = dLat / dPsi
+	}
+	// From a pole the line runs along the meridian of start.
+	dLon := 0.0
+	if q != 0 {
+		dLon = delta * math.Sin(theta) / q
+	}
+
+	return polaris.NewPosition(lat2*180/math.Pi, angNormalize(start.Longitude+dLon*180/math.Pi))
+}
+
+// stretchedLatitudeDifference returns the difference of the Mercator
+// ordinates of the latitudes, given in radians. It is infinite if one of
+// them is a pole.
+func stretchedLatitudeDifference(lat1, lat2 float64) float64 {
+	return mercator(lat2) - mercator(lat1)
+}
+
+// mercator returns the Mercator ordinate ln(tan(π/4 + lat/2)) of a latitude
+// in radians, ±Inf at the poles.
+func mercator(lat float64) float64 {
+	if math.Abs(lat) >= math.Pi/2 {
+		return math.Copysign(math.Inf(1), lat)
+	}
+	return math.Atanh(math.Sin(lat))
+}
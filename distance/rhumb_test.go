@@ -0,0 +1,173 @@
+package distance
+
+import (
+	"math"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/ethz-polymaps/polaris"
+)
+
+func TestRhumbDistance(t *testing.T) {
+	degree := SphericalEarth().Radius * math.Pi / 180
+
+	tests := []struct {
+		name        string
+		a, b        polaris.Position
+		wantDist    float64
+		distDelta   float64
+		wantBearing float64
+	}{
+		{
+			// Dover to Calais, from Chris Veness, "Calculate distance,
+			// bearing and more between Latitude/Longitude points".
+			name:        "dover to calais",
+			a:           polaris.NewPosition(51.127, 1.338),
+			b:           polaris.NewPosition(50.964, 1.853),
+			wantDist:    40310,
+			distDelta:   10,
+			wantBearing: 116.7,
+		},
+		{
+			name:        "equator",
+			a:           polaris.NewPosition(0, 10),
+			b:           polaris.NewPosition(0, 20),
+			wantDist:    10 * degree,
+			distDelta:   1e-6,
+			wantBearing: 90,
+		},
+		{
+			name:        "parallel",
+			a:           polaris.NewPosition(60, 10),
+			b:           polaris.NewPosition(60, 0),
+			wantDist:    5 * degree,
+			distDelta:   1e-6,
+			wantBearing: 270,
+		},
+		{
+			name:        "across antimeridian",
+			a:           polaris.NewPosition(0, 179),
+			b:           polaris.NewPosition(0, -179),
+			wantDist:    2 * degree,
+			distDelta:   1e-6,
+			wantBearing: 90,
+		},
+		{
+			name:        "meridian",
+			a:           polaris.NewPosition(10, 5),
+			b:           polaris.NewPosition(-20, 5),
+			wantDist:    30 * degree,
+			distDelta:   1e-6,
+			wantBearing: 180,
+		},
+		{
+			name:        "to pole",
+			a:           polaris.NewPosition(80, 30),
+			b:           polaris.NewPosition(90, 0),
+			wantDist:    10 * degree,
+			distDelta:   1e-6,
+			wantBearing: 0,
+		},
+		{
+			name:        "from pole",
+			a:           polaris.NewPosition(-90, 0),
+			b:           polaris.NewPosition(-75, 120),
+			wantDist:    15 * degree,
+			distDelta:   1e-6,
+			wantBearing: 0,
+		},
+		{
+			name:        "same pole",
+			a:           polaris.NewPosition(90, 0),
+			b:           polaris.NewPosition(90, 45),
+			wantDist:    0,
+			distDelta:   1e-6,
+			wantBearing: 0,
+		},
+		{
+			name:        "coincident",
+			a:           polaris.NewPosition(47.3769, 8.5417),
+			b:           polaris.NewPosition(47.3769, 8.5417),
+			wantDist:    0,
+			distDelta:   0,
+			wantBearing: 0,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.InDelta(t, tt.wantDist, RhumbDistance(tt.a, tt.b), tt.distDelta)
+			assert.InDelta(t, tt.wantBearing, RhumbBearing(tt.a, tt.b), 0.05)
+		})
+	}
+}
+
+func TestRhumbDistance_notShorterThanGreatCircle(t *testing.T) {
+	zurich := polaris.NewPosition(47.3769, 8.5417)
+	newYork := polaris.NewPosition(40.7128, -74.0060)
+
+	rhumb := RhumbDistance(zurich, newYork)
+	greatCircle := HaversineDistance(zurich, newYork)
+	assert.Greater(t, rhumb, greatCircle)
+	assert.InDelta(t, rhumb, RhumbDistance(newYork, zurich), 1e-6)
+	assert.InDelta(t, normalizeBearing(RhumbBearing(zurich, newYork)+180), RhumbBearing(newYork, zurich), 1e-9)
+}
+
+func TestRhumbDestination(t *testing.T) {
+	tests := []struct {
+		name    string
+		start   polaris.Position
+		bearing float64
+		meters  float64
+		want    polaris.Position
+	}{
+		{
+			name:    "east along equator",
+			start:   polaris.NewPosition(0, 10),
+			bearing: 90,
+			meters:  RhumbDistance(polaris.NewPosition(0, 10), polaris.NewPosition(0, 20)),
+			want:    polaris.NewPosition(0, 20),
+		},
+		{
+			name:    "across antimeridian",
+			start:   polaris.NewPosition(60, 175),
+			bearing: 90,
+			meters:  RhumbDistance(polaris.NewPosition(60, 175), polaris.NewPosition(60, -175)),
+			want:    polaris.NewPosition(60, -175),
+		},
+		{
+			name:    "past the pole",
+			start:   polaris.NewPosition(85, 20),
+			bearing: 45,
+			meters:  2000000,
+			want:    polaris.NewPosition(90, 20),
+		},
+		{
+			name:    "from the pole",
+			start:   polaris.NewPosition(90, 20),
+			bearing: 180,
+			meters:  RhumbDistance(polaris.NewPosition(90, 20), polaris.NewPosition(80, 20)),
+			want:    polaris.NewPosition(80, 20),
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := RhumbDestination(tt.start, tt.bearing, tt.meters)
+			assert.InDelta(t, tt.want.Latitude, got.Latitude, 1e-9)
+			assert.InDelta(t, tt.want.Longitude, got.Longitude, 1e-9)
+		})
+	}
+}
+
+func TestRhumbDestination_roundTrip(t *testing.T) {
+	pairs := [][2]polaris.Position{
+		{polaris.NewPosition(47.3769, 8.5417), polaris.NewPosition(40.7128, -74.0060)},
+		{polaris.NewPosition(-33.8568, 151.2153), polaris.NewPosition(-36.8485, -174.7633)},
+		{polaris.NewPosition(51.127, 1.338), polaris.NewPosition(50.964, 1.853)},
+	}
+	for _, p := range pairs {
+		got := RhumbDestination(p[0], RhumbBearing(p[0], p[1]), RhumbDistance(p[0], p[1]))
+		assert.InDelta(t, p[1].Latitude, got.Latitude, 1e-9)
+		assert.InDelta(t, p[1].Longitude, got.Longitude, 1e-9)
+	}
+}
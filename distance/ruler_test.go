@@ -0,0 +1,102 @@
+package distance
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/ethz-polymaps/polaris"
+)
+
+func TestRuler_Distance(t *testing.T) {
+	tests := []struct {
+		name     string
+		latitude float64
+		meters   float64
+		maxError float64
+	}{
+		{"equator 500 km", 0, 500000, 0.001},
+		{"zurich 500 km", 47.4, 500000, 0.001},
+		{"zurich 1 km", 47.4, 1000, 1e-6},
+		{"oslo 500 km", 60, 500000, 0.001},
+		{"oslo 1 km", 60, 1000, 1e-6},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			origin := polaris.NewPosition(tt.latitude, 8.5)
+			for bearing := 0.0; bearing < 360; bearing += 15 {
+				dest, _ := VincentyDestination(origin, bearing, tt.meters)
+				ruler := NewRuler((origin.Latitude + dest.Latitude) / 2)
+
+				want := KarneyDistance(origin, dest)
+				got := ruler.Distance(origin, dest)
+				assert.InDelta(t, want, got, tt.maxError*want, "%v°", bearing)
+			}
+		})
+	}
+}
+
+func TestRuler_Bearing(t *testing.T) {
+	ruler := NewRuler(47.4)
+	origin := polaris.NewPosition(47.4, 8.5)
+
+	for _, bearing := range []float64{0, 30, 90, 150, 180, 270, 345} {
+		dest, _ := VincentyDestination(origin, bearing, 1000)
+		assert.InDelta(t, bearing, ruler.Bearing(origin, dest), 0.01, "%v°", bearing)
+	}
+	assert.Zero(t, ruler.Bearing(origin, origin))
+}
+
+func TestRuler_Offset(t *testing.T) {
+	ruler := NewRuler(47.4)
+	origin := polaris.NewPosition(47.4, 8.5)
+
+	east := ruler.Offset(origin, 500, 0)
+	assert.Equal(t, origin.Latitude, east.Latitude)
+	assert.InDelta(t, 500, VincentyDistance(origin, east), 0.01)
+	assert.InDelta(t, 90, VincentyInitialBearing(origin, east), 0.01)
+
+	north := ruler.Offset(origin, 0, -300)
+	assert.Equal(t, origin.Longitude, north.Longitude)
+	assert.InDelta(t, 300, VincentyDistance(origin, north), 0.01)
+
+	dest := ruler.Destination(origin, 60, 800)
+	assert.InDelta(t, 800, ruler.Distance(origin, dest), 1e-9)
+	assert.InDelta(t, 60, ruler.Bearing(origin, dest), 1e-9)
+}
+
+func TestRuler_antimeridian(t *testing.T) {
+	ruler := NewRuler(-17)
+	west := polaris.NewPosition(-17, 179.999)
+	east := polaris.NewPosition(-17, -179.999)
+
+	assert.InDelta(t, VincentyDistance(west, east), ruler.Distance(west, east), 1e-3)
+	assert.InDelta(t, 90, ruler.Bearing(west, east), 1e-9)
+	assert.InDelta(t, 270, ruler.Bearing(east, west), 1e-9)
+
+	dest := ruler.Offset(west, ruler.Distance(west, east), 0)
+	assert.InDelta(t, east.Longitude, dest.Longitude, 1e-9)
+}
+
+func BenchmarkDistance(b *testing.B) {
+	anchor := polaris.NewPosition(47.41331, 8.53644)
+	tag := polaris.NewPosition(47.41328, 8.53646)
+	ruler := NewRuler(anchor.Latitude)
+
+	funcs := []struct {
+		name         string
+		distanceFunc func(a, b polaris.Position) float64
+	}{
+		{"ruler", ruler.Distance},
+		{"haversine", HaversineDistance},
+		{"vincenty", VincentyDistance},
+		{"karney", KarneyDistance},
+	}
+	for _, f := range funcs {
+		b.Run(f.name, func(b *testing.B) {
+			for b.Loop() {
+				f.distanceFunc(anchor, tag)
+			}
+		})
+	}
+}
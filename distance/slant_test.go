@@ -0,0 +1,66 @@
+package distance
+
+import (
+	"math"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/ethz-polymaps/polaris"
+)
+
+func TestSlantDistance(t *testing.T) {
+	tests := []struct {
+		name string
+		a, b polaris.Position3D
+		want float64
+	}{
+		{"same position", polaris.NewPosition3D(47.4, 8.5, 10), polaris.NewPosition3D(47.4, 8.5, 10), 0},
+		{"vertical", polaris.NewPosition3D(10, 20, 0), polaris.NewPosition3D(10, 20, 100), 100},
+		{"equatorial diameter", polaris.NewPosition3D(0, 0, 0), polaris.NewPosition3D(0, 180, 0), 2 * WGS84().SemiMajorAxis},
+		{"polar diameter", polaris.NewPosition3D(90, 0, 0), polaris.NewPosition3D(-90, 0, 0), 2 * WGS84().SemiMinorAxis()},
+		{"polar diameter at altitude", polaris.NewPosition3D(90, 0, 1000), polaris.NewPosition3D(-90, 0, 1000), 2*WGS84().SemiMinorAxis() + 2000},
+		// A right triangle between a point on the equator, the one 90° east
+		// and the center of the Earth.
+		{"quarter of the equator", polaris.NewPosition3D(0, 0, 0), polaris.NewPosition3D(0, 90, 0), math.Sqrt2 * WGS84().SemiMajorAxis},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.InDelta(t, tt.want, SlantDistance(tt.a, tt.b), 1e-6)
+			assert.InDelta(t, tt.want, SlantDistance(tt.b, tt.a), 1e-6)
+		})
+	}
+}
+
+func TestSlantDistance_shorterThanGeodesic(t *testing.T) {
+	zurich := polaris.NewPosition3D(47.3769, 8.5417, 0)
+	bern := polaris.NewPosition3D(46.9480, 7.4474, 0)
+	assert.Less(t, SlantDistance(zurich, bern), KarneyDistance(zurich.Position, bern.Position))
+	// The chord of a 96 km arc is about d³/24R² = 0.9 m shorter.
+	assert.InDelta(t, KarneyDistance(zurich.Position, bern.Position)-0.9, SlantDistance(zurich, bern), 0.05)
+}
+
+func TestNewSlant(t *testing.T) {
+	tag := polaris.NewPosition(47.4133, 8.5364)
+	for _, altitude := range []float64{0, 410, 3000, -400} {
+		for _, meters := range []float64{0, 1, 10, 100, 1000, 10000} {
+			for _, height := range []float64{0, 3.5, -3.5, 120} {
+				anchor, _ := VincentyDestination(tag, 30, meters)
+				want := SlantDistance(
+					polaris.Position3D{Position: tag, Altitude: altitude},
+					polaris.Position3D{Position: anchor, Altitude: altitude + height},
+				)
+				got := NewSlant(KarneyDistance, altitude, height)(tag, anchor)
+				tolerance := 1e-3
+				if meters > 1000 {
+					tolerance = 5e-3
+				}
+				assert.InDelta(t, want, got, tolerance, "%v m at %v m altitude and %v m height", meters, altitude, height)
+			}
+		}
+	}
+
+	// On the ground the ranges are sqrt(ground² + height²).
+	assert.Equal(t, 5.0, NewSlant(func(a, b polaris.Position) float64 { return 0 }, 0, 5)(tag, tag))
+	assert.InDelta(t, 5.0, NewSlant(func(a, b polaris.Position) float64 { return 4 }, 0, 3)(tag, tag), 1e-6)
+}
@@ -0,0 +1,184 @@
+package distance
+
+import (
+	"math"
+
+	"github.com/ethz-polymaps/polaris"
+)
+
+// Snap is the position on a polyline closest to a given position.
+type Snap struct {
+	// Position is the closest position on the polyline.
+	Position polaris.Position
+	// Distance is the distance in meters from the given position to
+	// Position.
+	Distance float64
+	// Segment is the index i of the segment from path[i] to path[i+1] that
+	// contains Position.
+	Segment int
+}
+
+// HaversineCrossTrackDistance returns the distance in meters of p from the
+// great circle through start and end on the spherical Earth used by
+// [HaversineDistance]. The distance is positive if p lies to the right of the
+// direction from start to end and negative if it lies to the left.
+func HaversineCrossTrackDistance(p, start, end polaris.Position) float64 {
+	_, cross := sphericalIntercept(p, start, end)
+	return cross * earthRadius
+}
+
+// HaversineAlongTrackDistance returns the distance in meters from start to
+// the point of the great circle through start and end closest to p, on the
+// spherical Earth used by [HaversineDistance]. The distance is negative if
+// that point lies behind start.
+func HaversineAlongTrackDistance(p, start, end polaris.Position) float64 {
+	along, _ := sphericalIntercept(p, start, end)
+	return along * earthRadius
+}
+
+// HaversineClosestPoint returns the position on the great-circle segment from
+// start to end closest to p. If the closest point of the great circle lies
+// outside the segment, it returns the nearer of start and end.
+func HaversineClosestPoint(p, start, end polaris.Position) polaris.Position {
+	inv := HaversineInverse(start, end)
+	along := HaversineAlongTrackDistance(p, start, end)
+	if along <= 0 || along >= inv.Distance {
+		return nearer(p, start, end, HaversineDistance)
+	}
+	foot, _ := HaversineDestination(start, inv.InitialBearing, along)
+	return foot
+}
+
+// HaversineDistanceToPolyline returns the distance in meters from p to the
+// closest point of the path of great-circle segments through the given
+// positions, on the spherical Earth used by [HaversineDistance]. It returns
+// +Inf for an empty path.
+func HaversineDistanceToPolyline(p polaris.Position, path []polaris.Position) float64 {
+	return HaversineSnapToPolyline(p, path).Distance
+}
+
+// HaversineSnapToPolyline returns the point of the path of great-circle
+// segments through the given positions closest to p, for example to snap an
+// estimate to a walking path. For an empty path the distance is +Inf and the
+// segment is -1.
+func HaversineSnapToPolyline(p polaris.Position, path []polaris.Position) Snap {
+	return snapToPolyline(p, path, HaversineClosestPoint, HaversineDistance)
+}
+
+// VincentyCrossTrackDistance returns the distance in meters of p from the
+// geodesic through start and end on the WGS-84 ellipsoid. The distance is
+// positive if p lies to the right of the direction from start to end and
+// negative if it lies to the left.
+//
+// The point of the geodesic closest to p is found iteratively with the method
+// of S. Baselga and J. C. Martínez-Llario, "Intersection and point-to-line
+// solutions for geodesics on the ellipsoid", Stud. Geophys. Geod. 62 (2018),
+// using [VincentyInverse] and [VincentyDestination] in every step.
+func VincentyCrossTrackDistance(p, start, end polaris.Position) float64 {
+	_, cross, _ := vincentyIntercept(p, start, VincentyInitialBearing(start, end))
+	return cross
+}
+
+// VincentyAlongTrackDistance returns the distance in meters along the
+// geodesic through start and end on the WGS-84 ellipsoid from start to the
+// point closest to p. The distance is negative if that point lies behind
+// start.
+func VincentyAlongTrackDistance(p, start, end polaris.Position) float64 {
+	along, _, _ := vincentyIntercept(p, start, VincentyInitialBearing(start, end))
+	return along
+}
+
+// VincentyClosestPoint returns the position on the geodesic segment from
+// start to end on the WGS-84 ellipsoid closest to p. If the closest point of
+// the geodesic lies outside the segment, it returns the nearer of start and
+// end.
+func VincentyClosestPoint(p, start, end polaris.Position) polaris.Position {
+	inv := VincentyInverse(start, end)
+	along, _, foot := vincentyIntercept(p, start, inv.InitialBearing)
+	if along <= 0 || along >= inv.Distance {
+		return nearer(p, start, end, VincentyDistance)
+	}
+	return foot
+}
+
+// VincentyDistanceToPolyline returns the distance in meters from p to the
+// closest point of the path of geodesic segments through the given positions
+// on the WGS-84 ellipsoid. It returns +Inf for an empty path.
+func VincentyDistanceToPolyline(p polaris.Position, path []polaris.Position) float64 {
+	return VincentySnapToPolyline(p, path).Distance
+}
+
+// VincentySnapToPolyline returns the point of the path of geodesic segments
+// through the given positions on the WGS-84 ellipsoid closest to p. For an
+// empty path the distance is +Inf and the segment is -1.
+func VincentySnapToPolyline(p polaris.Position, path []polaris.Position) Snap {
+	return snapToPolyline(p, path, VincentyClosestPoint, VincentyDistance)
+}
+
+// sphericalIntercept returns the along-track and cross-track distances of p
+// relative to the great circle from start to end as angles in radians.
+func sphericalIntercept(p, start, end polaris.Position) (along, cross float64) {
+	d13 := HaversineDistance(start, p) / earthRadius
+	theta := (HaversineInitialBearing(start, p) - HaversineInitialBearing(start, end)) * math.Pi / 180
+
+	cross = math.Asin(math.Sin(d13) * math.Sin(theta))
+	// In the right spherical triangle of start, p and the closest point,
+	// tan(along) = tan(d13) cos(theta).
+	along = math.Atan2(math.Sin(d13)*math.Cos(theta), math.Cos(d13))
+	return along, cross
+}
+
+// vincentyIntercept returns the along-track and cross-track distances in
+// meters of p relative to the geodesic leaving start at the given bearing,
+// together with the closest point of the geodesic.
+func vincentyIntercept(p, start polaris.Position, bearing float64) (along, cross float64, foot polaris.Position) {
+	foot, azimuth := start, bearing
+	for i := 0; i < 20; i++ {
+		inv := VincentyInverse(foot, p)
+		if inv.Distance == 0 {
+			return along, 0, foot
+		}
+		// Solve the right spherical triangle between the current point, p
+		// and the closest point on a sphere of the mean radius.
+		angle := (inv.InitialBearing - azimuth) * math.Pi / 180
+		d := inv.Distance / earthRadius
+		step := math.Atan2(math.Sin(d)*math.Cos(angle), math.Cos(d)) * earthRadius
+
+		cross = math.Copysign(inv.Distance, math.Sin(angle))
+		if math.Abs(step) < 1e-6 {
+			break
+		}
+		along += step
+		foot, azimuth = VincentyDestination(start, bearing, along)
+	}
+	return along, cross, foot
+}
+
+// nearer returns whichever of a and b is closer to p.
+func nearer(p, a, b polaris.Position, distanceFunc func(a, b polaris.Position) float64) polaris.Position {
+	if distanceFunc(p, b) < distanceFunc(p, a) {
+		return b
+	}
+	return a
+}
+
+// snapToPolyline returns the closest point to p over all segments of path.
+func snapToPolyline(p polaris.Position, path []polaris.Position,
+	closestPoint func(p, start, end polaris.Position) polaris.Position,
+	distanceFunc func(a, b polaris.Position) float64) Snap {
+	switch len(path) {
+	case 0:
+		return Snap{Distance: math.Inf(1), Segment: -1}
+	case 1:
+		return Snap{Position: path[0], Distance: distanceFunc(p, path[0])}
+	}
+
+	best := Snap{Distance: math.Inf(1), Segment: -1}
+	for i := 0; i+1 < len(path); i++ {
+		c := closestPoint(p, path[i], path[i+1])
+		if d := distanceFunc(p, c); d < best.Distance {
+			best = Snap{Position: c, Distance: d, Segment: i}
+		}
+	}
+	return best
+}
@@ -0,0 +1,146 @@
+package distance
+
+import (
+	"math"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/ethz-polymaps/polaris"
+)
+
+func TestHaversineCrossTrackDistance(t *testing.T) {
+	// Reference values from Chris Veness, "Calculate distance, bearing and
+	// more between Latitude/Longitude points" (movable-type.co.uk).
+	p := polaris.NewPosition(53.2611, -0.7972)
+	start := polaris.NewPosition(53.3206, -1.7297)
+	end := polaris.NewPosition(53.1887, 0.1334)
+
+	assert.InDelta(t, -307.5, HaversineCrossTrackDistance(p, start, end), 0.05)
+	assert.InDelta(t, 62331, HaversineAlongTrackDistance(p, start, end), 0.5)
+
+	// Reversing the path moves p to the other side.
+	assert.InDelta(t, 307.5, HaversineCrossTrackDistance(p, end, start), 0.05)
+}
+
+func TestHaversineTrack(t *testing.T) {
+	equatorStart := polaris.NewPosition(0, 0)
+	equatorEnd := polaris.NewPosition(0, 10)
+	degree := SphericalEarth().Radius * math.Pi / 180
+
+	tests := []struct {
+		name      string
+		p         polaris.Position
+		start     polaris.Position
+		end       polaris.Position
+		wantCross float64
+		wantAlong float64
+	}{
+		{"north of equator is left", polaris.NewPosition(1, 5), equatorStart, equatorEnd, -degree, 5 * degree},
+		{"south of equator is right", polaris.NewPosition(-2, 3), equatorStart, equatorEnd, -2 * -degree, 3 * degree},
+		{"behind start", polaris.NewPosition(0, -4), equatorStart, equatorEnd, 0, -4 * degree},
+		{"on path", polaris.NewPosition(0, 7), equatorStart, equatorEnd, 0, 7 * degree},
+		{"across antimeridian", polaris.NewPosition(1, 180), polaris.NewPosition(0, 175), polaris.NewPosition(0, -175), -degree, 5 * degree},
+		{"meridian towards pole", polaris.NewPosition(85, 90), polaris.NewPosition(80, 0), polaris.NewPosition(90, 0), 5 * degree, 10 * degree},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.InDelta(t, tt.wantCross, HaversineCrossTrackDistance(tt.p, tt.start, tt.end), 1e-6)
+			assert.InDelta(t, tt.wantAlong, HaversineAlongTrackDistance(tt.p, tt.start, tt.end), 1e-6)
+		})
+	}
+}
+
+func TestClosestPoint(t *testing.T) {
+	start := polaris.NewPosition(0, 0)
+	end := polaris.NewPosition(0, 10)
+
+	tests := []struct {
+		name         string
+		closestPoint func(p, start, end polaris.Position) polaris.Position
+	}{
+		{"haversine", HaversineClosestPoint},
+		{"vincenty", VincentyClosestPoint},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := tt.closestPoint(polaris.NewPosition(1, 4), start, end)
+			assert.InDelta(t, 0, got.Latitude, 1e-9)
+			assert.InDelta(t, 4, got.Longitude, 1e-6)
+
+			assert.Equal(t, start, tt.closestPoint(polaris.NewPosition(1, -3), start, end))
+			assert.Equal(t, end, tt.closestPoint(polaris.NewPosition(-1, 12), start, end))
+			assert.Equal(t, start, tt.closestPoint(polaris.NewPosition(1, 1), start, start))
+		})
+	}
+}
+
+func TestVincentyTrack(t *testing.T) {
+	start := polaris.NewPosition(47.3769, 8.5417)
+	end := polaris.NewPosition(46.9480, 7.4474)
+	p := polaris.NewPosition(47.2, 8.1)
+
+	cross := VincentyCrossTrackDistance(p, start, end)
+	along := VincentyAlongTrackDistance(p, start, end)
+	foot := VincentyClosestPoint(p, start, end)
+
+	// The closest point lies on the geodesic, at the along-track distance
+	// from start and the cross-track distance from p, and the geodesic to p
+	// leaves it at a right angle.
+	assert.InDelta(t, along, VincentyDistance(start, foot), 1e-4)
+	assert.InDelta(t, VincentyDistance(start, end), along+VincentyDistance(foot, end), 1e-4)
+	assert.InDelta(t, math.Abs(cross), VincentyDistance(foot, p), 1e-4)
+	assert.InDelta(t, 90, math.Abs(VincentyInitialBearing(foot, p)-VincentyInitialBearing(foot, end)), 1e-6)
+
+	// p lies south-east of the path towards the south-west, which is left.
+	assert.Less(t, cross, 0.0)
+	assert.InDelta(t, HaversineCrossTrackDistance(p, start, end), cross, 0.005*math.Abs(cross))
+	assert.InDelta(t, HaversineAlongTrackDistance(p, start, end), along, 0.005*along)
+
+	// Points on the geodesic have no cross-track distance.
+	on := VincentyIntermediate(start, end, 0.3)
+	assert.InDelta(t, 0, VincentyCrossTrackDistance(on, start, end), 1e-4)
+	assert.InDelta(t, 0.3*VincentyDistance(start, end), VincentyAlongTrackDistance(on, start, end), 1e-4)
+}
+
+func TestSnapToPolyline(t *testing.T) {
+	path := []polaris.Position{
+		polaris.NewPosition(0, 0),
+		polaris.NewPosition(0, 0.01),
+		polaris.NewPosition(0.01, 0.01),
+	}
+
+	tests := []struct {
+		name         string
+		snap         func(p polaris.Position, path []polaris.Position) Snap
+		distance     func(p polaris.Position, path []polaris.Position) float64
+		distanceFunc func(a, b polaris.Position) float64
+	}{
+		{"haversine", HaversineSnapToPolyline, HaversineDistanceToPolyline, HaversineDistance},
+		{"vincenty", VincentySnapToPolyline, VincentyDistanceToPolyline, VincentyDistance},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			// Next to the second segment.
+			p := polaris.NewPosition(0.004, 0.0105)
+			got := tt.snap(p, path)
+			assert.Equal(t, 1, got.Segment)
+			assert.InDelta(t, 0.004, got.Position.Latitude, 1e-7)
+			assert.InDelta(t, 0.01, got.Position.Longitude, 1e-9)
+			assert.InDelta(t, tt.distanceFunc(p, got.Position), got.Distance, 1e-9)
+			assert.InDelta(t, got.Distance, tt.distance(p, path), 1e-9)
+
+			// Beyond the corner the corner is closest.
+			corner := tt.snap(polaris.NewPosition(-0.001, 0.011), path)
+			assert.Equal(t, path[1], corner.Position)
+
+			single := tt.snap(p, path[:1])
+			assert.Equal(t, 0, single.Segment)
+			assert.Equal(t, path[0], single.Position)
+
+			empty := tt.snap(p, nil)
+			assert.Equal(t, -1, empty.Segment)
+			assert.True(t, math.IsInf(tt.distance(p, nil), 1))
+		})
+	}
+}
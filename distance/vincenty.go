@@ -6,19 +6,68 @@ import (
 	"github.com/ethz-polymaps/polaris"
 )
 
-// WGS-84 ellipsoid parameters
-const (
-	wgs84A = 6378137.0         // Semi-major axis in meters
-	wgs84B = 6356752.314245    // Semi-minor axis in meters
-	wgs84F = 1 / 298.257223563 // Flattening
-)
-
 // VincentyDistance calculates the distance between two points using Vincenty's formula.
 // This is more accurate than Haversine as it accounts for Earth's ellipsoidal shape (WGS-84).
 // Returns distance in meters.
 func VincentyDistance(a, b polaris.Position) float64 {
+	g, _ := vincentySolve(WGS84(), a, b)
+	return g.distance
+}
+
+// VincentyInitialBearing returns the forward azimuth of the geodesic from a
+// to b on the WGS-84 ellipsoid at a, in degrees clockwise from north in the
+// range [0, 360).
+func VincentyInitialBearing(a, b polaris.Position) float64 {
+	return VincentyInverse(a, b).InitialBearing
+}
+
+// VincentyFinalBearing returns the azimuth of the geodesic from a to b on the
+// WGS-84 ellipsoid at b, in degrees clockwise from north in the range [0, 360).
+func VincentyFinalBearing(a, b polaris.Position) float64 {
+	return VincentyInverse(a, b).FinalBearing
+}
+
+// VincentyInverse solves the inverse geodesic problem on the WGS-84 ellipsoid
+// using Vincenty's formulae, returning the distance and both bearings at once.
+// For coincident points the bearings are zero.
+func VincentyInverse(a, b polaris.Position) Inverse {
+	return vincentyInverse(WGS84(), a, b)
+}
+
+// vincentyInverse solves the inverse geodesic problem on the ellipsoid e.
+func vincentyInverse(e Ellipsoid, a, b polaris.Position) Inverse {
+	g, ok := vincentySolve(e, a, b)
+	if !ok {
+		return Inverse{}
+	}
+
+	sinLambda, cosLambda := math.Sin(g.lambda), math.Cos(g.lambda)
+	alpha1 := math.Atan2(g.cosU2*sinLambda, g.cosU1*g.sinU2-g.sinU1*g.cosU2*cosLambda)
+	alpha2 := math.Atan2(g.cosU1*sinLambda, -g.sinU1*g.cosU2+g.cosU1*g.sinU2*cosLambda)
+
+	return Inverse{
+		Distance:       g.distance,
+		InitialBearing: normalizeBearing(alpha1 * 180 / math.Pi),
+		FinalBearing:   normalizeBearing(alpha2 * 180 / math.Pi),
+	}
+}
+
+// vincentyGeodesic holds the converged state of Vincenty's inverse iteration,
+// from which the bearings follow without iterating again.
+type vincentyGeodesic struct {
+	distance                   float64
+	lambda                     float64
+	sinU1, cosU1, sinU2, cosU2 float64
+}
+
+// vincentySolve runs Vincenty's inverse iteration between a and b on the
+// ellipsoid e. It reports false for coincident points, whose distance is zero
+// and whose bearings are undefined.
+func vincentySolve(e Ellipsoid, a, b polaris.Position) (vincentyGeodesic, bool) {
+	semiMajor, semiMinor, f := e.SemiMajorAxis, e.SemiMinorAxis(), e.Flattening
+
 	if a.Latitude == b.Latitude && a.Longitude == b.Longitude {
-		return 0
+		return vincentyGeodesic{}, false
 	}
 
 	lat1 := a.Latitude * math.Pi / 180
@@ -27,8 +76,8 @@ func VincentyDistance(a, b polaris.Position) float64 {
 	lon2 := b.Longitude * math.Pi / 180
 
 	L := lon2 - lon1
-	U1 := math.Atan((1 - wgs84F) * math.Tan(lat1))
-	U2 := math.Atan((1 - wgs84F) * math.Tan(lat2))
+	U1 := math.Atan((1 - f) * math.Tan(lat1))
+	U2 := math.Atan((1 - f) * math.Tan(lat2))
 
 	sinU1, cosU1 := math.Sin(U1), math.Cos(U1)
 	sinU2, cosU2 := math.Sin(U2), math.Cos(U2)
@@ -44,7 +93,7 @@ func VincentyDistance(a, b polaris.Position) float64 {
 				(cosU1*sinU2-sinU1*cosU2*cosLambda)*(cosU1*sinU2-sinU1*cosU2*cosLambda))
 
 		if sinSigma == 0 {
-			return 0 // Co-incident points
+			return vincentyGeodesic{}, false // Co-incident points
 		}
 
 		cosSigma = sinU1*sinU2 + cosU1*cosU2*cosLambda
@@ -52,15 +101,14 @@ func VincentyDistance(a, b polaris.Position) float64 {
 
 		sinAlpha = cosU1 * cosU2 * sinLambda / sinSigma
 		cos2Alpha = 1 - sinAlpha*sinAlpha
-		cos2SigmaM = cosSigma - 2*sinU1*sinU2/cos2Alpha
-
-		if math.IsNaN(cos2SigmaM) {
-			cos2SigmaM = 0 // Equatorial line
+		cos2SigmaM = 0 // Equatorial line
+		if cos2Alpha != 0 {
+			cos2SigmaM = cosSigma - 2*sinU1*sinU2/cos2Alpha
 		}
 
-		C := wgs84F / 16 * cos2Alpha * (4 + wgs84F*(4-3*cos2Alpha))
+		C := f / 16 * cos2Alpha * (4 + f*(4-3*cos2Alpha))
 		lambdaPrev := lambda
-		lambda = L + (1-C)*wgs84F*sinAlpha*
+		lambda = L + (1-C)*f*sinAlpha*
 			(sigma+C*sinSigma*(cos2SigmaM+C*cosSigma*(-1+2*cos2SigmaM*cos2SigmaM)))
 
 		if math.Abs(lambda-lambdaPrev) < 1e-12 {
@@ -68,12 +116,85 @@ func VincentyDistance(a, b polaris.Position) float64 {
 		}
 	}
 
-	uSq := cos2Alpha * (wgs84A*wgs84A - wgs84B*wgs84B) / (wgs84B * wgs84B)
+	uSq := cos2Alpha * (semiMajor*semiMajor - semiMinor*semiMinor) / (semiMinor * semiMinor)
 	A := 1 + uSq/16384*(4096+uSq*(-768+uSq*(320-175*uSq)))
 	B := uSq / 1024 * (256 + uSq*(-128+uSq*(74-47*uSq)))
 
 	deltaSigma := B * sinSigma * (cos2SigmaM + B/4*(cosSigma*(-1+2*cos2SigmaM*cos2SigmaM)-
 		B/6*cos2SigmaM*(-3+4*sinSigma*sinSigma)*(-3+4*cos2SigmaM*cos2SigmaM)))
 
-	return wgs84B * A * (sigma - deltaSigma)
+	return vincentyGeodesic{
+		distance: semiMinor * A * (sigma - deltaSigma),
+		lambda:   lambda,
+		sinU1:    sinU1,
+		cosU1:    cosU1,
+		sinU2:    sinU2,
+		cosU2:    cosU2,
+	}, true
+}
+
+// VincentyDestination calculates the position reached by travelling the given
+// distance in meters along a geodesic on the WGS-84 ellipsoid from start,
+// leaving at initialBearing degrees clockwise from north. It solves the direct
+// geodesic problem using Vincenty's formulae.
+//
+// It returns the destination and the final bearing in degrees in the range
+// [0, 360), which is the direction of travel on arrival.
+func VincentyDestination(start polaris.Position, initialBearing, meters float64) (dest polaris.Position, finalBearing float64) {
+	return vincentyDirect(WGS84(), start, initialBearing, meters)
+}
+
+// vincentyDirect solves the direct geodesic problem on the ellipsoid e.
+func vincentyDirect(e Ellipsoid, start polaris.Position, initialBearing, meters float64) (dest polaris.Position, finalBearing float64) {
+	semiMajor, semiMinor, f := e.SemiMajorAxis, e.SemiMinorAxis(), e.Flattening
+
+	lat1 := start.Latitude * math.Pi / 180
+	alpha1 := initialBearing * math.Pi / 180
+	sinAlpha1, cosAlpha1 := math.Sin(alpha1), math.Cos(alpha1)
+
+	tanU1 := (1 - f) * math.Tan(lat1)
+	cosU1 := 1 / math.Sqrt(1+tanU1*tanU1)
+	sinU1 := tanU1 * cosU1
+
+	sigma1 := math.Atan2(tanU1, cosAlpha1)
+	sinAlpha := cosU1 * sinAlpha1
+	cos2Alpha := 1 - sinAlpha*sinAlpha
+
+	uSq := cos2Alpha * (semiMajor*semiMajor - semiMinor*semiMinor) / (semiMinor * semiMinor)
+	A := 1 + uSq/16384*(4096+uSq*(-768+uSq*(320-175*uSq)))
+	B := uSq / 1024 * (256 + uSq*(-128+uSq*(74-47*uSq)))
+
+	sigma := meters / (semiMinor * A)
+	var sinSigma, cosSigma, cos2SigmaM float64
+
+	for i := 0; i < 100; i++ {
+		cos2SigmaM = math.Cos(2*sigma1 + sigma)
+		sinSigma, cosSigma = math.Sin(sigma), math.Cos(sigma)
+
+		deltaSigma := B * sinSigma * (cos2SigmaM + B/4*(cosSigma*(-1+2*cos2SigmaM*cos2SigmaM)-
+			B/6*cos2SigmaM*(-3+4*sinSigma*sinSigma)*(-3+4*cos2SigmaM*cos2SigmaM)))
+
+		sigmaPrev := sigma
+		sigma = meters/(semiMinor*A) + deltaSigma
+
+		if math.Abs(sigma-sigmaPrev) < 1e-12 {
+			break
+		}
+	}
+
+	cos2SigmaM = math.Cos(2*sigma1 + sigma)
+	sinSigma, cosSigma = math.Sin(sigma), math.Cos(sigma)
+
+	x := sinU1*sinSigma - cosU1*cosSigma*cosAlpha1
+	lat2 := math.Atan2(sinU1*cosSigma+cosU1*sinSigma*cosAlpha1, (1-f)*math.Sqrt(sinAlpha*sinAlpha+x*x))
+	lambda := math.Atan2(sinSigma*sinAlpha1, cosU1*cosSigma-sinU1*sinSigma*cosAlpha1)
+
+	C := f / 16 * cos2Alpha * (4 + f*(4-3*cos2Alpha))
+	L := lambda - (1-C)*f*sinAlpha*
+		(sigma+C*sinSigma*(cos2SigmaM+C*cosSigma*(-1+2*cos2SigmaM*cos2SigmaM)))
+
+	final := math.Atan2(sinAlpha, -x)
+
+	dest = polaris.NewPosition(lat2*180/math.Pi, angNormalize(start.Longitude+L*180/math.Pi))
+	return dest, normalizeBearing(final * 180 / math.Pi)
 }
@@ -1,6 +1,7 @@
 package distance
 
 import (
+	"math"
 	"testing"
 
 	"github.com/stretchr/testify/assert"
@@ -28,6 +29,15 @@ func TestVincentyDistance(t *testing.T) {
 			want:  0,
 			delta: 0,
 		},
+		{
+			name: "equatorial with tiny latitudes",
+			args: args{
+				a: polaris.NewPosition(2.2150663691708397e-19, 0.003593261136478086),
+				b: polaris.NewPosition(4.43013272957094e-19, 0.007186522272956172),
+			},
+			want:  400,
+			delta: 1e-6,
+		},
 		{
 			name: "short distance ~5.7m",
 			args: args{
@@ -63,3 +73,119 @@ func TestVincentyDistance(t *testing.T) {
 		})
 	}
 }
+
+func TestVincentyDestination(t *testing.T) {
+	type args struct {
+		start   polaris.Position
+		bearing float64
+		meters  float64
+	}
+	tests := []struct {
+		name         string
+		args         args
+		want         polaris.Position
+		finalBearing float64
+		delta        float64
+	}{
+		{
+			name: "zero distance",
+			args: args{
+				start:   polaris.NewPosition(47.413310, 8.536444),
+				bearing: 45,
+				meters:  0,
+			},
+			want:         polaris.NewPosition(47.413310, 8.536444),
+			finalBearing: 45,
+			delta:        1e-12,
+		},
+		{
+			name: "quarter of the equator",
+			args: args{
+				start:   polaris.NewPosition(0, 0),
+				bearing: 90,
+				meters:  WGS84().SemiMajorAxis * math.Pi / 2,
+			},
+			want:         polaris.NewPosition(0, 90),
+			finalBearing: 90,
+			delta:        1e-9,
+		},
+		{
+			// Vincenty (1975), Flinders Peak to Buninyong.
+			name: "flinders peak to buninyong",
+			args: args{
+				start:   polaris.NewPosition(-37.95103342, 144.42486789),
+				bearing: 306.86815972,
+				meters:  54972.271,
+			},
+			want:         polaris.NewPosition(-37.65282114, 143.92649554),
+			finalBearing: 307.17363056,
+			delta:        1e-6,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			dest, finalBearing := VincentyDestination(tt.args.start, tt.args.bearing, tt.args.meters)
+			assert.InDelta(t, tt.want.Latitude, dest.Latitude, tt.delta)
+			assert.InDelta(t, tt.want.Longitude, dest.Longitude, tt.delta)
+			assert.InDelta(t, tt.finalBearing, finalBearing, tt.delta)
+
+			// Travelling there and measuring back must give the distance.
+			assert.InDelta(t, tt.args.meters, VincentyDistance(tt.args.start, dest), 0.0001)
+		})
+	}
+}
+
+func TestVincentyInverse(t *testing.T) {
+	type args struct {
+		a polaris.Position
+		b polaris.Position
+	}
+	tests := []struct {
+		name  string
+		args  args
+		want  Inverse
+		delta float64
+	}{
+		{
+			name: "same point",
+			args: args{
+				a: polaris.NewPosition(47.413310, 8.536444),
+				b: polaris.NewPosition(47.413310, 8.536444),
+			},
+			want:  Inverse{},
+			delta: 0,
+		},
+		{
+			// Vincenty (1975), Flinders Peak to Buninyong.
+			name: "flinders peak to buninyong",
+			args: args{
+				a: polaris.NewPosition(-37.95103342, 144.42486789),
+				b: polaris.NewPosition(-37.65282114, 143.92649554),
+			},
+			want:  Inverse{Distance: 54972.271, InitialBearing: 306.86815972, FinalBearing: 307.17363056},
+			delta: 1e-3,
+		},
+		{
+			// Reference case from the GeographicLib test suite (GeodTest.dat).
+			name: "geodtest 1",
+			args: args{
+				a: polaris.NewPosition(35.60777, -139.44815),
+				b: polaris.NewPosition(-11.17491, -69.95921),
+			},
+			want:  Inverse{Distance: 8935244.5604818305, InitialBearing: 111.098748429560326, FinalBearing: 129.289270889708762},
+			delta: 1e-3,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			result := VincentyInverse(tt.args.a, tt.args.b)
+			assert.InDelta(t, tt.want.Distance, result.Distance, tt.delta)
+			assert.InDelta(t, tt.want.InitialBearing, result.InitialBearing, tt.delta)
+			assert.InDelta(t, tt.want.FinalBearing, result.FinalBearing, tt.delta)
+
+			assert.Equal(t, result.Distance, VincentyDistance(tt.args.a, tt.args.b))
+			assert.Equal(t, result.InitialBearing, VincentyInitialBearing(tt.args.a, tt.args.b))
+			assert.Equal(t, result.FinalBearing, VincentyFinalBearing(tt.args.a, tt.args.b))
+		})
+	}
+}
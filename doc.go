@@ -4,11 +4,22 @@
 // and trilateration capabilities for position estimation from multiple reference points.
 //
 // The core type is [Position], which represents a geographic location using
-// latitude and longitude coordinates in decimal degrees.
+// latitude and longitude coordinates in decimal degrees. [ParsePosition] reads
+// positions in decimal, degrees-minutes-seconds, ISO 6709 and geo URI form,
+// and [Position.Format] writes them in each of these styles.
+// [Position.Validate] checks the coordinate ranges and [Position.Normalize]
+// wraps out-of-range coordinates around the poles and the antimeridian.
+//
+// [Position3D] adds an altitude in meters above the WGS-84 ellipsoid for
+// anchors and targets at different heights.
+//
+// [BoundingBox] is an area between two parallels and two meridians. It is
+// built from positions with [NewBoundingBox] or from a circle with
+// [NewBoundingBoxAround], and handles boxes that cross the antimeridian.
 //
 // # Distance Calculations
 //
-// The distance subpackage provides two methods for calculating distances:
+// The distance subpackage provides three methods for calculating distances:
 //
 //   - [distance.HaversineDistance]: Uses the Haversine formula assuming a spherical Earth.
 //     Faster but less accurate for long distances.
@@ -16,6 +27,9 @@
 //   - [distance.VincentyDistance]: Uses Vincenty's formulae with the WGS-84 ellipsoid model.
 //     More accurate, especially for long distances.
 //
+//   - [distance.KarneyDistance]: Uses Karney's algorithm with the WGS-84 ellipsoid model.
+//     Accurate to nanometers and converges for nearly antipodal points.
+//
 // # Trilateration
 //
 // The trilateration subpackage estimates a position from multiple distance measurements
@@ -25,6 +39,39 @@
 //   - GPS-denied navigation
 //   - Beacon-based localization
 //
+// # Projections
+//
+// The projection subpackage converts positions to and from projected
+// coordinate systems such as the Swiss LV95 and LV03 grids, UTM and MGRS, and
+// to Earth-centered and local East-North-Up Cartesian frames.
+//
+// # GeoJSON
+//
+// The geojson subpackage writes positions, measurement anchors and estimates
+// with their accuracy circle as GeoJSON and reads anchors back. [Position]
+// implements [encoding/json.Marshaler] and [encoding/json.Unmarshaler].
+//
+// # Polygons
+//
+// The geometry subpackage models rooms, buildings and campuses as polygons
+// with holes and tests whether positions lie inside them, including polygons
+// across the antimeridian and around the poles.
+//
+// # Geohash
+//
+// The geohash subpackage encodes positions as geohashes, finds neighboring
+// cells and covers circles with cells for prefix queries.
+//
+// # Polylines
+//
+// The polyline subpackage encodes paths of positions in the compact Encoded
+// Polyline Algorithm Format.
+//
+// # Spatial Index
+//
+// The spatial subpackage indexes positions in a k-d tree for nearest-neighbor,
+// radius and bounding box queries.
+//
 // # Example
 //
 //	zurich := polaris.NewPosition(47.3769, 8.5417)
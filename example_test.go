@@ -22,3 +22,73 @@ func ExamplePosition_String() {
 	// Output:
 	// 47.376900,8.541700
 }
+
+func ExampleParsePosition() {
+	for _, s := range []string{
+		"47.3769,8.5417",
+		`47°22'36.8"N 8°32'30.1"E`,
+		"+47.3769+008.5417/",
+		"geo:47.3769,8.5417;u=10",
+	} {
+		pos, err := polaris.ParsePosition(s)
+		if err != nil {
+			panic(err)
+		}
+		fmt.Printf("%.4f, %.4f\n", pos.Latitude, pos.Longitude)
+	}
+	// Output:
+	// 47.3769, 8.5417
+	// 47.3769, 8.5417
+	// 47.3769, 8.5417
+	// 47.3769, 8.5417
+}
+
+func ExamplePosition_Format() {
+	pos := polaris.NewPosition(47.3769, 8.5417)
+	fmt.Println(pos.Format(polaris.Decimal, 4))
+	fmt.Println(pos.Format(polaris.DMS, 1))
+	fmt.Println(pos.Format(polaris.DDM, 3))
+	fmt.Println(pos.Format(polaris.ISO6709, 4))
+	fmt.Println(pos.Format(polaris.GeoURI, 4))
+	// Output:
+	// 47.3769,8.5417
+	// 47°22'36.8"N 8°32'30.1"E
+	// 47°22.614'N 8°32.502'E
+	// +47.3769+008.5417/
+	// geo:47.3769,8.5417
+}
+
+func ExamplePosition_Normalize() {
+	pos := polaris.NewPosition(95, 190)
+	fmt.Println(pos.IsValid())
+	fmt.Println(pos.Validate())
+
+	pos = pos.Normalize()
+	fmt.Println(pos, pos.IsValid())
+	// Output:
+	// false
+	// invalid latitude 95: outside [-90, 90]
+	// 85.000000,10.000000 true
+}
+
+func ExampleNewBoundingBox() {
+	anchors := []polaris.Position{
+		polaris.NewPosition(-16.5, 179.5),
+		polaris.NewPosition(-18.1, 178.4),
+		polaris.NewPosition(-17.5, -179.6),
+	}
+	box, err := polaris.NewBoundingBox(anchors)
+	if err != nil {
+		panic(err)
+	}
+	fmt.Println(box)
+	fmt.Println(box.CrossesAntimeridian(), box.Contains(polaris.NewPosition(-17, 180)))
+
+	// Grow the box by 10 km on every side to search around the anchors.
+	search := box.Expand(10000)
+	fmt.Println(search)
+	// Output:
+	// -18.100000,178.400000,-16.500000,-179.600000
+	// true true
+	// -18.189932,178.305386,-16.410068,-179.505386
+}
@@ -0,0 +1,136 @@
+package polaris
+
+import (
+	"math"
+	"strconv"
+	"strings"
+)
+
+// Style selects the notation used by [Position.Format].
+type Style int
+
+const (
+	// Decimal formats decimal degrees separated by a comma, as in
+	// "47.3769,8.5417".
+	Decimal Style = iota
+	// DMS formats degrees, minutes and seconds with hemisphere letters, as in
+	// `47°22'36.8"N 8°32'30.1"E`.
+	DMS
+	// DDM formats degrees and decimal minutes with hemisphere letters, as in
+	// "47°22.614'N 8°32.502'E".
+	DDM
+	// ISO6709 formats an ISO 6709 point in signed decimal degrees, as in
+	// "+47.3769+008.5417/".
+	ISO6709
+	// GeoURI formats an RFC 5870 geo URI, as in "geo:47.3769,8.5417".
+	GeoURI
+)
+
+// String returns the name of the style.
+func (s Style) String() string {
+	switch s {
+	case Decimal:
+		return "Decimal"
+	case DMS:
+		return "DMS"
+	case DDM:
+		return "DDM"
+	case ISO6709:
+		return "ISO6709"
+	case GeoURI:
+		return "GeoURI"
+	default:
+		return "Style(" + strconv.Itoa(int(s)) + ")"
+	}
+}
+
+// Format returns the position in the given style. The precision is the number
+// of decimal places of the smallest unit: degrees for [Decimal], [ISO6709] and
+// [GeoURI], minutes for [DDM] and seconds for [DMS]. A precision of -1 uses
+// the smallest number of digits necessary to represent the value exactly.
+//
+// Rounding carries over into the larger units, so 59.99 seconds at precision
+// 1 become a full minute. All styles can be read back with [ParsePosition].
+// An unknown style formats as [Decimal].
+func (l Position) Format(style Style, precision int) string {
+	switch style {
+	case DMS:
+		return formatSexagesimal(l.Latitude, "NS", precision, 3) + " " +
+			formatSexagesimal(l.Longitude, "EW", precision, 3)
+	case DDM:
+		return formatSexagesimal(l.Latitude, "NS", precision, 2) + " " +
+			formatSexagesimal(l.Longitude, "EW", precision, 2)
+	case ISO6709:
+		return formatISO6709(l.Latitude, 2, precision) + formatISO6709(l.Longitude, 3, precision) + "/"
+	case GeoURI:
+		return "geo:" + formatDecimal(l.Latitude, precision) + "," + formatDecimal(l.Longitude, precision)
+	default:
+		return formatDecimal(l.Latitude, precision) + "," + formatDecimal(l.Longitude, precision)
+	}
+}
+
+func formatDecimal(v float64, precision int) string {
+	return strconv.FormatFloat(v, 'f', precision, 64)
+}
+
+// formatISO6709 formats a signed coordinate whose integer degrees are padded
+// with zeros to the given number of digits.
+func formatISO6709(v float64, degreeDigits, precision int) string {
+	sign := "+"
+	if v < 0 {
+		sign = "-"
+	}
+	s := strconv.FormatFloat(math.Abs(v), 'f', precision, 64)
+	intDigits := strings.IndexByte(s, '.')
+	if intDigits < 0 {
+		intDigits = len(s)
+	}
+	if intDigits < degreeDigits {
+		s = strings.Repeat("0", degreeDigits-intDigits) + s
+	}
+	if strings.Trim(s, "0.") == "" {
+		sign = "+"
+	}
+	return sign + s
+}
+
+// formatSexagesimal formats a coordinate with the given number of components,
+// 2 for degrees and minutes or 3 for degrees, minutes and seconds, followed by
+// the hemisphere letter from hemispheres for positive and negative values.
+func formatSexagesimal(v float64, hemispheres string, precision, components int) string {
+	// Work in the smallest unit so that rounding carries over.
+	unit := 60.0
+	if components == 3 {
+		unit = 3600
+	}
+	total := math.Abs(v) * unit
+	if precision >= 0 {
+		scale := math.Pow(10, float64(precision))
+		total = math.Round(total*scale) / scale
+	}
+
+	hemisphere := hemispheres[0]
+	if v < 0 && total != 0 {
+		hemisphere = hemispheres[1]
+	}
+
+	deg := math.Floor(total / unit)
+	rest := total - deg*unit
+
+	var b strings.Builder
+	b.WriteString(strconv.FormatFloat(deg, 'f', 0, 64))
+	b.WriteString("°")
+	if components == 3 {
+		min := math.Floor(rest / 60)
+		sec := rest - min*60
+		b.WriteString(strconv.FormatFloat(min, 'f', 0, 64))
+		b.WriteString("'")
+		b.WriteString(strconv.FormatFloat(sec, 'f', precision, 64))
+		b.WriteString(`"`)
+	} else {
+		b.WriteString(strconv.FormatFloat(rest, 'f', precision, 64))
+		b.WriteString("'")
+	}
+	b.WriteByte(hemisphere)
+	return b.String()
+}
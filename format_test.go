@@ -0,0 +1,80 @@
+package polaris
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestPosition_Format(t *testing.T) {
+	zurich := NewPosition(47.3769, 8.5417)
+	sydney := NewPosition(-33.8568, -151.2153)
+
+	tests := []struct {
+		name      string
+		pos       Position
+		style     Style
+		precision int
+		want      string
+	}{
+		{name: "decimal", pos: zurich, style: Decimal, precision: 4, want: "47.3769,8.5417"},
+		{name: "decimal rounding", pos: zurich, style: Decimal, precision: 2, want: "47.38,8.54"},
+		{name: "decimal shortest", pos: zurich, style: Decimal, precision: -1, want: "47.3769,8.5417"},
+		{name: "decimal negative", pos: sydney, style: Decimal, precision: 6, want: "-33.856800,-151.215300"},
+		{name: "dms", pos: zurich, style: DMS, precision: 1, want: `47°22'36.8"N 8°32'30.1"E`},
+		{name: "dms no decimals", pos: zurich, style: DMS, precision: 0, want: `47°22'37"N 8°32'30"E`},
+		{name: "dms south west", pos: sydney, style: DMS, precision: 2, want: `33°51'24.48"S 151°12'55.08"W`},
+		{name: "dms carry", pos: NewPosition(47.99999, -0.99999), style: DMS, precision: 0, want: `48°0'0"N 1°0'0"W`},
+		{name: "dms zero", pos: NewPosition(-0.000001, 0), style: DMS, precision: 1, want: `0°0'0.0"N 0°0'0.0"E`},
+		{name: "ddm", pos: zurich, style: DDM, precision: 3, want: "47°22.614'N 8°32.502'E"},
+		{name: "ddm carry", pos: NewPosition(10.99999, 20), style: DDM, precision: 2, want: "11°0.00'N 20°0.00'E"},
+		{name: "iso 6709", pos: zurich, style: ISO6709, precision: 4, want: "+47.3769+008.5417/"},
+		{name: "iso 6709 negative", pos: sydney, style: ISO6709, precision: 4, want: "-33.8568-151.2153/"},
+		{name: "iso 6709 no decimals", pos: NewPosition(5, 5), style: ISO6709, precision: 0, want: "+05+005/"},
+		{name: "iso 6709 negative zero", pos: NewPosition(-0.00001, 0), style: ISO6709, precision: 2, want: "+00.00+000.00/"},
+		{name: "geo uri", pos: zurich, style: GeoURI, precision: 4, want: "geo:47.3769,8.5417"},
+		{name: "unknown style", pos: zurich, style: Style(42), precision: 1, want: "47.4,8.5"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.want, tt.pos.Format(tt.style, tt.precision))
+		})
+	}
+}
+
+func TestPosition_FormatRoundTrip(t *testing.T) {
+	positions := []Position{
+		NewPosition(47.3769, 8.5417),
+		NewPosition(-33.8568, 151.2153),
+		NewPosition(-89.123456, -179.987654),
+		NewPosition(0, 0),
+	}
+	tests := []struct {
+		style     Style
+		precision int
+		delta     float64
+	}{
+		{style: Decimal, precision: 6, delta: 1e-6},
+		{style: DMS, precision: 3, delta: 0.001 / 3600},
+		{style: DDM, precision: 5, delta: 0.00001 / 60},
+		{style: ISO6709, precision: 6, delta: 1e-6},
+		{style: GeoURI, precision: -1, delta: 0},
+	}
+	for _, tt := range tests {
+		t.Run(tt.style.String(), func(t *testing.T) {
+			for _, p := range positions {
+				s := p.Format(tt.style, tt.precision)
+				got, err := ParsePosition(s)
+				require.NoError(t, err, s)
+				assert.InDelta(t, p.Latitude, got.Latitude, tt.delta, s)
+				assert.InDelta(t, p.Longitude, got.Longitude, tt.delta, s)
+			}
+		})
+	}
+}
+
+func TestStyle_String(t *testing.T) {
+	assert.Equal(t, "DMS", DMS.String())
+	assert.Equal(t, "Style(42)", Style(42).String())
+}
@@ -0,0 +1,145 @@
+package geohash
+
+import (
+	"fmt"
+	"math"
+	"sort"
+
+	"github.com/ethz-polymaps/polaris"
+	"github.com/ethz-polymaps/polaris/distance"
+)
+
+// MaxCoverCells limits the number of geohashes returned by [Cover].
+const MaxCoverCells = 1 << 16
+
+// coverMargin enlarges the radius to account for the difference of up to
+// about 0.5% between spherical and ellipsoidal distances.
+const coverMargin = 1.01
+
+// Cover returns the sorted geohashes of the given precision whose cells
+// intersect the circle of radius meters around center. Every position within
+// the circle lies in one of the returned cells, which makes the result
+// suitable for prefix queries against an index of geohashes.
+//
+// Distances are computed on the sphere used by [distance.HaversineDistance]
+// with a margin of 1% so that the cover also holds for ellipsoidal distances.
+// Circles around a pole and across the antimeridian are supported. An error
+// is returned if more than [MaxCoverCells] cells would be needed; choose a
+// lower precision in that case.
+func Cover(center polaris.Position, radius float64, precision int) ([]string, error) {
+	if err := center.Validate(); err != nil {
+		return nil, err
+	}
+	if radius < 0 || math.IsNaN(radius) {
+		return nil, fmt.Errorf("invalid radius %v", radius)
+	}
+	if precision < 1 || precision > MaxPrecision {
+		return nil, fmt.Errorf("precision %d outside [1, %d]", precision, MaxPrecision)
+	}
+	center = center.Normalize()
+
+	r := radius * coverMargin
+	angle := r / distance.SphericalEarth().Radius * 180 / math.Pi
+
+	latBits, lonBits := bits(precision)
+	height, width := CellSize(precision)
+	rows, cols := 1<<latBits, 1<<lonBits
+
+	minLat := math.Max(center.Latitude-angle, -90)
+	maxLat := math.Min(center.Latitude+angle, 90)
+	firstRow := min(int(math.Floor((minLat+90)/height)), rows-1)
+	lastRow := min(int(math.Floor((maxLat+90)/height)), rows-1)
+
+	// The longitude extent of a spherical cap, or every column if the cap
+	// contains a pole.
+	firstCol, colCount := 0, cols
+	if center.Latitude+angle < 90 && center.Latitude-angle > -90 {
+		halfWidth := 180.0
+		if s := math.Sin(r/distance.SphericalEarth().Radius) / math.Cos(center.Latitude*math.Pi/180); s < 1 {
+			halfWidth = math.Asin(s) * 180 / math.Pi
+		}
+		if halfWidth < 180 {
+			firstCol = int(math.Floor((center.Longitude - halfWidth + 180) / width))
+			lastCol := int(math.Floor((center.Longitude + halfWidth + 180) / width))
+			colCount = min(lastCol-firstCol+1, cols)
+		}
+	}
+
+	if n := (lastRow - firstRow + 1) * colCount; n > MaxCoverCells {
+		return nil, fmt.Errorf("cover needs %d cells, more than %d", n, MaxCoverCells)
+	}
+
+	var hashes []string
+	for row := firstRow; row <= lastRow; row++ {
+		for i := 0; i < colCount; i++ {
+			col := ((firstCol+i)%cols + cols) % cols
+			b := Box{
+				MinLat: float64(row)*height - 90,
+				MaxLat: float64(row+1)*height - 90,
+				MinLon: float64(col)*width - 180,
+				MaxLon: float64(col+1)*width - 180,
+			}
+			if boxDistance(center, b) <= r {
+				hashes = append(hashes, cellHash(row, col, precision))
+			}
+		}
+	}
+	sort.Strings(hashes)
+	return hashes, nil
+}
+
+// cellHash returns the geohash of the cell in the given row and column by
+// interleaving their bits, longitude first.
+func cellHash(row, col, precision int) string {
+	latBits, lonBits := bits(precision)
+	hash := make([]byte, precision)
+	even := true
+	for i := range hash {
+		var idx byte
+		for bit := 0; bit < 5; bit++ {
+			idx <<= 1
+			if even {
+				lonBits--
+				idx |= byte(col >> lonBits & 1)
+			} else {
+				latBits--
+				idx |= byte(row >> latBits & 1)
+			}
+			even = !even
+		}
+		hash[i] = base32[idx]
+	}
+	return string(hash)
+}
+
+// boxDistance returns the spherical distance in meters from p to the nearest
+// point of the box.
+func boxDistance(p polaris.Position, b Box) float64 {
+	clampedLat := math.Max(b.MinLat, math.Min(p.Latitude, b.MaxLat))
+
+	// Longitude offsets of the box edges from p, in (-180, 180].
+	west := math.Remainder(b.MinLon-p.Longitude, 360)
+	east := math.Remainder(b.MaxLon-p.Longitude, 360)
+	if west <= 0 && east >= 0 || b.MaxLon-b.MinLon >= 360 {
+		// The meridian of p crosses the box, so the nearest point lies on it.
+		return distance.SphericalEarth().Distance(p, polaris.NewPosition(clampedLat, p.Longitude))
+	}
+
+	// Otherwise the nearest point lies on the closer meridian edge.
+	edge := b.MinLon
+	dLon := west
+	if math.Abs(east) < math.Abs(west) {
+		edge, dLon = b.MaxLon, east
+	}
+	best := math.Min(
+		distance.SphericalEarth().Distance(p, polaris.NewPosition(b.MinLat, edge)),
+		distance.SphericalEarth().Distance(p, polaris.NewPosition(b.MaxLat, edge)),
+	)
+	if math.Abs(dLon) < 90 {
+		// Foot of the perpendicular from p onto the meridian great circle.
+		foot := math.Atan(math.Tan(p.Latitude*math.Pi/180)/math.Cos(dLon*math.Pi/180)) * 180 / math.Pi
+		foot = math.Max(b.MinLat, math.Min(foot, b.MaxLat))
+		best = math.Min(best, distance.SphericalEarth().Distance(p, polaris.NewPosition(foot, edge)))
+	}
+	return best
+}
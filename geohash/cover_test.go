@@ -0,0 +1,119 @@
+package geohash
+
+import (
+	"math"
+	"slices"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/ethz-polymaps/polaris"
+	"github.com/ethz-polymaps/polaris/distance"
+)
+
+func TestCover(t *testing.T) {
+	tests := []struct {
+		name      string
+		center    polaris.Position
+		radius    float64
+		precision int
+	}{
+		{name: "zurich 500 m", center: polaris.NewPosition(47.3769, 8.5417), radius: 500, precision: 6},
+		{name: "zurich 5 km", center: polaris.NewPosition(47.3769, 8.5417), radius: 5000, precision: 5},
+		{name: "tiny radius", center: polaris.NewPosition(47.3769, 8.5417), radius: 1, precision: 7},
+		{name: "antimeridian", center: polaris.NewPosition(-16.5, 179.99), radius: 20000, precision: 4},
+		{name: "north pole", center: polaris.NewPosition(89.9, 45), radius: 50000, precision: 3},
+		{name: "south pole", center: polaris.NewPosition(-90, 0), radius: 100000, precision: 3},
+		{name: "high latitude", center: polaris.NewPosition(78.2232, 15.6267), radius: 10000, precision: 5},
+		{name: "continent", center: polaris.NewPosition(0, 0), radius: 3000000, precision: 2},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			hashes, err := Cover(tt.center, tt.radius, tt.precision)
+			require.NoError(t, err)
+			require.NotEmpty(t, hashes)
+			assert.True(t, slices.IsSorted(hashes))
+			assert.Len(t, slices.Compact(slices.Clone(hashes)), len(hashes))
+			assert.Contains(t, hashes, mustEncode(t, tt.center, tt.precision))
+
+			// Every sampled point of the circle lies in a returned cell.
+			for bearing := 0.0; bearing < 360; bearing += 5 {
+				for _, f := range []float64{0.5, 0.999} {
+					p, _ := distance.VincentyDestination(tt.center, bearing, f*tt.radius)
+					hash := mustEncode(t, p, tt.precision)
+					assert.Contains(t, hashes, hash, "bearing %v at %v", bearing, f)
+				}
+			}
+
+			// Every returned cell comes close to the circle.
+			for _, hash := range hashes {
+				_, box, err := Decode(hash)
+				require.NoError(t, err)
+				assert.LessOrEqual(t, boxDistance(tt.center, box), tt.radius*coverMargin, hash)
+			}
+		})
+	}
+}
+
+func TestCover_cellCount(t *testing.T) {
+	// A small circle in the middle of a cell needs only that cell.
+	center, _, err := Decode("u0qjd2")
+	require.NoError(t, err)
+	hashes, err := Cover(center, 10, 6)
+	require.NoError(t, err)
+	assert.Equal(t, []string{"u0qjd2"}, hashes)
+
+	// A circle much larger than a cell needs roughly area / cell area cells.
+	hashes, err = Cover(center, 2000, 6)
+	require.NoError(t, err)
+	height, width := CellSize(6)
+	cellArea := height * 111195 * width * 111195 * math.Cos(center.Latitude*math.Pi/180)
+	circleArea := math.Pi * 2000 * 2000
+	assert.InDelta(t, circleArea/cellArea, float64(len(hashes)), circleArea/cellArea)
+}
+
+func TestCover_invalid(t *testing.T) {
+	p := polaris.NewPosition(47.3769, 8.5417)
+
+	_, err := Cover(polaris.NewPosition(91, 0), 100, 5)
+	assert.Error(t, err)
+	_, err = Cover(p, -1, 5)
+	assert.Error(t, err)
+	_, err = Cover(p, 100, 0)
+	assert.Error(t, err)
+	_, err = Cover(p, 100, MaxPrecision+1)
+	assert.Error(t, err)
+	_, err = Cover(p, 100000, 8)
+	assert.ErrorContains(t, err, "more than")
+}
+
+func TestBoxDistance(t *testing.T) {
+	b := Box{MinLat: 10, MaxLat: 20, MinLon: 30, MaxLon: 40}
+	tests := []struct {
+		name string
+		box  Box
+		pos  polaris.Position
+		want polaris.Position
+	}{
+		{name: "inside", box: b, pos: polaris.NewPosition(15, 35), want: polaris.NewPosition(15, 35)},
+		{name: "south", box: b, pos: polaris.NewPosition(5, 35), want: polaris.NewPosition(10, 35)},
+		{name: "corner", box: b, pos: polaris.NewPosition(0, 20), want: polaris.NewPosition(10, 30)},
+		{
+			name: "across antimeridian",
+			box:  Box{MinLat: 10, MaxLat: 20, MinLon: 170, MaxLon: 180},
+			pos:  polaris.NewPosition(15, -175),
+			want: polaris.NewPosition(15, 180),
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			want := distance.HaversineDistance(tt.pos, tt.want)
+			// The nearest point on a meridian edge lies slightly poleward of
+			// the parallel through pos, so it may be a little closer.
+			got := boxDistance(tt.pos, tt.box)
+			assert.LessOrEqual(t, got, want+1e-6)
+			assert.InDelta(t, want, got, want*0.01+1e-6)
+		})
+	}
+}
@@ -0,0 +1,23 @@
+// Package geohash encodes positions as geohashes, the base-32 strings of
+// interleaved latitude and longitude bits used to bucket positions for
+// caching, deduplication and prefix queries.
+//
+// # Encoding and Decoding
+//
+// [Encode] returns the geohash of a [polaris.Position] with a given number of
+// characters. Every additional character narrows the cell by a factor of 32;
+// [CellSize] returns its dimensions. [Decode] returns the center of a cell
+// and its [Box]:
+//
+//	hash, err := geohash.Encode(pos, 7)         // "u0qjd2e"
+//	center, box, err := geohash.Decode(hash)
+//
+// # Neighbors and Covers
+//
+// [Neighbors] returns the eight cells around a geohash, wrapping around the
+// antimeridian. [Cover] returns the cells of a given precision that cover a
+// circle around a position, for example to look up all cached estimates
+// within 500 m:
+//
+//	hashes, err := geohash.Cover(pos, 500, 6)
+package geohash
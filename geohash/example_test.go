@@ -0,0 +1,52 @@
+package geohash_test
+
+import (
+	"fmt"
+
+	"github.com/ethz-polymaps/polaris"
+	"github.com/ethz-polymaps/polaris/geohash"
+)
+
+func ExampleEncode() {
+	zurich := polaris.NewPosition(47.3769, 8.5417)
+	hash, err := geohash.Encode(zurich, 7)
+	if err != nil {
+		panic(err)
+	}
+	fmt.Println(hash)
+	// Output:
+	// u0qjd2e
+}
+
+func ExampleDecode() {
+	center, box, err := geohash.Decode("u0qjd2e")
+	if err != nil {
+		panic(err)
+	}
+	fmt.Printf("%.5f, %.5f\n", center.Latitude, center.Longitude)
+	fmt.Printf("%.5f to %.5f, %.5f to %.5f\n", box.MinLat, box.MaxLat, box.MinLon, box.MaxLon)
+	// Output:
+	// 47.37648, 8.54118
+	// 47.37579 to 47.37717, 8.54050 to 8.54187
+}
+
+func ExampleNeighbors() {
+	neighbors, err := geohash.Neighbors("u0qjd2")
+	if err != nil {
+		panic(err)
+	}
+	fmt.Println(neighbors[geohash.North], neighbors[geohash.East], neighbors[geohash.South], neighbors[geohash.West])
+	// Output:
+	// u0qjd3 u0qjd8 u0qj6r u0qjd0
+}
+
+func ExampleCover() {
+	zurich := polaris.NewPosition(47.3769, 8.5417)
+	hashes, err := geohash.Cover(zurich, 500, 6)
+	if err != nil {
+		panic(err)
+	}
+	fmt.Println(len(hashes), hashes[0])
+	// Output:
+	// 7 u0qj6r
+}
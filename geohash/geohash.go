@@ -0,0 +1,216 @@
+package geohash
+
+import (
+	"errors"
+	"fmt"
+	"math"
+	"strings"
+
+	"github.com/ethz-polymaps/polaris"
+)
+
+// MaxPrecision is the longest supported geohash. At 12 characters a cell is
+// about 3.7 cm by 1.9 cm, below the resolution of float64 degrees near 180°.
+const MaxPrecision = 12
+
+// base32 is the geohash alphabet. It omits a, i, l and o.
+const base32 = "0123456789bcdefghjkmnpqrstuvwxyz"
+
+// Box is the area covered by a geohash cell in decimal degrees.
+type Box struct {
+	MinLat, MinLon float64
+	MaxLat, MaxLon float64
+}
+
+// Center returns the center of the box.
+func (b Box) Center() polaris.Position {
+	return polaris.NewPosition((b.MinLat+b.MaxLat)/2, (b.MinLon+b.MaxLon)/2)
+}
+
+// BoundingBox returns the box as a [polaris.BoundingBox].
+func (b Box) BoundingBox() polaris.BoundingBox {
+	return polaris.BoundingBox{
+		SouthWest: polaris.NewPosition(b.MinLat, b.MinLon),
+		NorthEast: polaris.NewPosition(b.MaxLat, b.MaxLon),
+	}
+}
+
+// Contains reports whether p lies in the box. Like the cells themselves, the
+// box includes its southern and western edges but not its northern and
+// eastern ones, except at the North Pole and the antimeridian.
+func (b Box) Contains(p polaris.Position) bool {
+	inLat := p.Latitude >= b.MinLat && (p.Latitude < b.MaxLat || b.MaxLat == 90 && p.Latitude == 90)
+	inLon := p.Longitude >= b.MinLon && (p.Longitude < b.MaxLon || b.MaxLon == 180 && p.Longitude == 180)
+	return inLat && inLon
+}
+
+// Encode returns the geohash of p with the given number of characters. The
+// precision is clamped to [1, MaxPrecision]. The position is normalized with
+// [polaris.Position.Normalize] first, so longitude 180 encodes like -180.
+// Positions with NaN or infinite coordinates have no cell and return an error
+// wrapping [polaris.ErrInvalidLatitude] or [polaris.ErrInvalidLongitude].
+func Encode(p polaris.Position, precision int) (string, error) {
+	precision = max(1, min(precision, MaxPrecision))
+	p = p.Normalize()
+	if err := p.Validate(); err != nil {
+		return "", err
+	}
+
+	latMin, latMax := -90.0, 90.0
+	lonMin, lonMax := -180.0, 180.0
+	hash := make([]byte, precision)
+	even := true
+	for i := range hash {
+		var idx byte
+		for bit := 0; bit < 5; bit++ {
+			idx <<= 1
+			if even {
+				mid := (lonMin + lonMax) / 2
+				if p.Longitude >= mid {
+					idx |= 1
+					lonMin = mid
+				} else {
+					lonMax = mid
+				}
+			} else {
+				mid := (latMin + latMax) / 2
+				if p.Latitude >= mid {
+					idx |= 1
+					latMin = mid
+				} else {
+					latMax = mid
+				}
+			}
+			even = !even
+		}
+		hash[i] = base32[idx]
+	}
+	return string(hash), nil
+}
+
+// Decode returns the center of the geohash cell and its box. Decoding is case
+// insensitive.
+func Decode(hash string) (polaris.Position, Box, error) {
+	b, err := decodeBox(hash)
+	if err != nil {
+		return polaris.EmptyPosition, Box{}, err
+	}
+	return b.Center(), b, nil
+}
+
+// decodeBox returns the box of a geohash.
+func decodeBox(hash string) (Box, error) {
+	if hash == "" {
+		return Box{}, errors.New("empty geohash")
+	}
+	if len(hash) > MaxPrecision {
+		return Box{}, fmt.Errorf("geohash %q longer than %d characters", hash, MaxPrecision)
+	}
+
+	b := Box{MinLat: -90, MaxLat: 90, MinLon: -180, MaxLon: 180}
+	even := true
+	for _, c := range strings.ToLower(hash) {
+		idx := strings.IndexRune(base32, c)
+		if idx < 0 {
+			return Box{}, fmt.Errorf("invalid character %q in geohash %q", c, hash)
+		}
+		for bit := 4; bit >= 0; bit-- {
+			set := idx>>bit&1 == 1
+			if even {
+				mid := (b.MinLon + b.MaxLon) / 2
+				if set {
+					b.MinLon = mid
+				} else {
+					b.MaxLon = mid
+				}
+			} else {
+				mid := (b.MinLat + b.MaxLat) / 2
+				if set {
+					b.MinLat = mid
+				} else {
+					b.MaxLat = mid
+				}
+			}
+			even = !even
+		}
+	}
+	return b, nil
+}
+
+// CellSize returns the height and width in degrees of a cell with the given
+// precision, clamped to [1, MaxPrecision].
+func CellSize(precision int) (latDegrees, lonDegrees float64) {
+	latBits, lonBits := bits(max(1, min(precision, MaxPrecision)))
+	return math.Ldexp(180, -latBits), math.Ldexp(360, -lonBits)
+}
+
+// bits returns the number of latitude and longitude bits of a geohash with
+// the given precision. Longitude takes the first bit of every pair.
+func bits(precision int) (latBits, lonBits int) {
+	n := 5 * precision
+	return n / 2, (n + 1) / 2
+}
+
+// Direction identifies one of the eight neighbors of a geohash cell.
+type Direction int
+
+// Directions in the order returned by [Neighbors].
+const (
+	North Direction = iota
+	NorthEast
+	East
+	SouthEast
+	South
+	SouthWest
+	West
+	NorthWest
+)
+
+// offsets are the latitude and longitude steps of each direction in cells.
+var offsets = [8][2]float64{
+	North:     {1, 0},
+	NorthEast: {1, 1},
+	East:      {0, 1},
+	SouthEast: {-1, 1},
+	South:     {-1, 0},
+	SouthWest: {-1, -1},
+	West:      {0, -1},
+	NorthWest: {1, -1},
+}
+
+// Neighbor returns the adjacent geohash of the same precision in the given
+// direction. Cells wrap around the antimeridian. North of the northernmost
+// row and south of the southernmost row there is no cell and the result is
+// the empty string.
+func Neighbor(hash string, d Direction) (string, error) {
+	if d < North || d > NorthWest {
+		return "", fmt.Errorf("invalid direction %d", d)
+	}
+	b, err := decodeBox(hash)
+	if err != nil {
+		return "", err
+	}
+
+	height, width := b.MaxLat-b.MinLat, b.MaxLon-b.MinLon
+	c := b.Center()
+	lat := c.Latitude + offsets[d][0]*height
+	if lat < -90 || lat > 90 {
+		return "", nil
+	}
+	lon := math.Remainder(c.Longitude+offsets[d][1]*width, 360)
+	return Encode(polaris.NewPosition(lat, lon), len(hash))
+}
+
+// Neighbors returns the eight adjacent geohashes in the order North,
+// NorthEast, East, SouthEast, South, SouthWest, West and NorthWest. See
+// [Neighbor] for the behavior at the poles and the antimeridian.
+func Neighbors(hash string) ([8]string, error) {
+	var n [8]string
+	for d := North; d <= NorthWest; d++ {
+		var err error
+		if n[d], err = Neighbor(hash, d); err != nil {
+			return [8]string{}, err
+		}
+	}
+	return n, nil
+}
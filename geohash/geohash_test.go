@@ -0,0 +1,165 @@
+package geohash
+
+import (
+	"math"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/ethz-polymaps/polaris"
+)
+
+func TestEncode(t *testing.T) {
+	tests := []struct {
+		name      string
+		pos       polaris.Position
+		precision int
+		want      string
+	}{
+		{name: "jutland", pos: polaris.NewPosition(57.64911, 10.40744), precision: 11, want: "u4pruydqqvj"},
+		{name: "jutland short", pos: polaris.NewPosition(57.64911, 10.40744), precision: 5, want: "u4pru"},
+		{name: "spain", pos: polaris.NewPosition(42.605, -5.603), precision: 5, want: "ezs42"},
+		{name: "null island", pos: polaris.NewPosition(0, 0), precision: 6, want: "s00000"},
+		{name: "south west corner", pos: polaris.NewPosition(-90, -180), precision: 4, want: "0000"},
+		{name: "north east corner", pos: polaris.NewPosition(90, 179.9999999), precision: 4, want: "zzzz"},
+		{name: "antimeridian wraps", pos: polaris.NewPosition(-90, 180), precision: 4, want: "0000"},
+		{name: "precision clamped low", pos: polaris.NewPosition(57.64911, 10.40744), precision: 0, want: "u"},
+		{name: "precision clamped high", pos: polaris.NewPosition(57.64911, 10.40744), precision: 20, want: "u4pruydqqvj8"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := Encode(tt.pos, tt.precision)
+			require.NoError(t, err)
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
+
+func TestEncode_invalid(t *testing.T) {
+	tests := []struct {
+		name    string
+		pos     polaris.Position
+		wantErr error
+	}{
+		{name: "latitude NaN", pos: polaris.NewPosition(math.NaN(), 8.5), wantErr: polaris.ErrInvalidLatitude},
+		{name: "latitude infinite", pos: polaris.NewPosition(math.Inf(-1), 8.5), wantErr: polaris.ErrInvalidLatitude},
+		{name: "longitude NaN", pos: polaris.NewPosition(47.4, math.NaN()), wantErr: polaris.ErrInvalidLongitude},
+		{name: "longitude infinite", pos: polaris.NewPosition(47.4, math.Inf(1)), wantErr: polaris.ErrInvalidLongitude},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			hash, err := Encode(tt.pos, 5)
+			assert.ErrorIs(t, err, tt.wantErr)
+			assert.Empty(t, hash)
+		})
+	}
+}
+
+func TestDecode(t *testing.T) {
+	center, box, err := Decode("ezs42")
+	require.NoError(t, err)
+	assert.InDelta(t, 42.605, center.Latitude, 0.0001)
+	assert.InDelta(t, -5.603, center.Longitude, 0.0001)
+	assert.InDelta(t, 42.583, box.MinLat, 0.001)
+	assert.InDelta(t, 42.627, box.MaxLat, 0.001)
+	assert.InDelta(t, -5.625, box.MinLon, 0.001)
+	assert.InDelta(t, -5.581, box.MaxLon, 0.001)
+
+	bb := box.BoundingBox()
+	assert.Equal(t, polaris.NewPosition(box.MinLat, box.MinLon), bb.SouthWest)
+	assert.Equal(t, polaris.NewPosition(box.MaxLat, box.MaxLon), bb.NorthEast)
+	assert.Equal(t, center, bb.Center())
+
+	upper, _, err := Decode("EZS42")
+	require.NoError(t, err)
+	assert.Equal(t, center, upper)
+
+	for _, hash := range []string{"", "ezs4a", "u4pruydqqvjkq"} {
+		_, _, err := Decode(hash)
+		assert.Error(t, err, hash)
+	}
+}
+
+func TestEncodeDecodeRoundTrip(t *testing.T) {
+	for lat := -89.5; lat < 90; lat += 7.7 {
+		for lon := -179.5; lon < 180; lon += 13.1 {
+			p := polaris.NewPosition(lat, lon)
+			for precision := 1; precision <= MaxPrecision; precision++ {
+				hash := mustEncode(t, p, precision)
+				require.Len(t, hash, precision)
+
+				center, box, err := Decode(hash)
+				require.NoError(t, err)
+				assert.True(t, box.Contains(p), "%v not in %s", p, hash)
+				assert.Equal(t, hash, mustEncode(t, center, precision))
+
+				height, width := CellSize(precision)
+				assert.InDelta(t, height, box.MaxLat-box.MinLat, 1e-12)
+				assert.InDelta(t, width, box.MaxLon-box.MinLon, 1e-12)
+			}
+		}
+	}
+}
+
+func TestCellSize(t *testing.T) {
+	height, width := CellSize(1)
+	assert.Equal(t, 45.0, height)
+	assert.Equal(t, 45.0, width)
+
+	height, width = CellSize(2)
+	assert.Equal(t, 5.625, height)
+	assert.Equal(t, 11.25, width)
+}
+
+func TestNeighbors(t *testing.T) {
+	got, err := Neighbors("gbsuv")
+	require.NoError(t, err)
+	assert.Equal(t, [8]string{"gbsvj", "gbsvn", "gbsuy", "gbsuw", "gbsut", "gbsus", "gbsuu", "gbsvh"}, got)
+
+	// The neighbors of a cell decode to adjacent boxes.
+	_, box, _ := Decode("u4pru")
+	for d, hash := range mustNeighbors(t, "u4pru") {
+		_, nb, err := Decode(hash)
+		require.NoError(t, err)
+		height, width := box.MaxLat-box.MinLat, box.MaxLon-box.MinLon
+		assert.InDelta(t, box.MinLat+offsets[d][0]*height, nb.MinLat, 1e-12)
+		assert.InDelta(t, box.MinLon+offsets[d][1]*width, nb.MinLon, 1e-12)
+	}
+}
+
+func TestNeighbors_edges(t *testing.T) {
+	// Across the antimeridian.
+	west := mustEncode(t, polaris.NewPosition(10, -179.99), 4)
+	east := mustEncode(t, polaris.NewPosition(10, 179.99), 4)
+	n := mustNeighbors(t, east)
+	assert.Equal(t, west, n[East])
+	assert.Equal(t, east, mustNeighbors(t, west)[West])
+
+	// Beyond the North Pole there are no cells.
+	north := mustEncode(t, polaris.NewPosition(89.99, 0), 3)
+	n = mustNeighbors(t, north)
+	assert.Empty(t, n[North])
+	assert.Empty(t, n[NorthEast])
+	assert.Empty(t, n[NorthWest])
+	assert.NotEmpty(t, n[South])
+
+	_, err := Neighbors("invalid")
+	assert.Error(t, err)
+	_, err = Neighbor("u4pru", Direction(8))
+	assert.Error(t, err)
+}
+
+func mustEncode(t *testing.T, p polaris.Position, precision int) string {
+	t.Helper()
+	hash, err := Encode(p, precision)
+	require.NoError(t, err)
+	return hash
+}
+
+func mustNeighbors(t *testing.T, hash string) [8]string {
+	t.Helper()
+	n, err := Neighbors(hash)
+	require.NoError(t, err)
+	return n
+}
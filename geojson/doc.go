@@ -0,0 +1,26 @@
+// Package geojson encodes positions and trilateration data as GeoJSON
+// (RFC 7946) and reads measurement anchors back.
+//
+// # Geometries and Features
+//
+// [Point], [Polygon] and [Circle] build [Geometry] values from
+// [polaris.Position] values. GeoJSON lists longitude before latitude; the
+// conversion takes care of the order. [Feature] and [FeatureCollection]
+// encode with the correct type members:
+//
+//	data, err := json.Marshal(geojson.NewFeature(geojson.Point(pos), nil))
+//	// {"type":"Feature","geometry":{"type":"Point","coordinates":[8.5417,47.3769]},"properties":null}
+//
+// # Trilateration
+//
+// [MeasurementCollection] writes measurement anchors as Point features with
+// distance and weight properties and the anchor altitude as an optional third
+// coordinate, and [EstimateCollection] writes an estimate together with its
+// accuracy circle as a Polygon, which is split into a MultiPolygon where it
+// crosses the antimeridian. [ParseMeasurements] reads an anchor
+// FeatureCollection back:
+//
+//	measurements, err := geojson.ParseMeasurements(data)
+//	position, accuracy, err := trilateration.NewTrilaterator().Trilaterate(measurements)
+//	out, err := json.Marshal(geojson.EstimateCollection(position, accuracy))
+package geojson
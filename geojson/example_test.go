@@ -0,0 +1,63 @@
+package geojson_test
+
+import (
+	"encoding/json"
+	"fmt"
+
+	"github.com/ethz-polymaps/polaris"
+	"github.com/ethz-polymaps/polaris/geojson"
+	"github.com/ethz-polymaps/polaris/trilateration"
+)
+
+func ExamplePoint() {
+	f := geojson.NewFeature(geojson.Point(polaris.NewPosition(47.3769, 8.5417)), map[string]any{"name": "Zurich"})
+
+	data, err := json.Marshal(f)
+	if err != nil {
+		panic(err)
+	}
+	fmt.Println(string(data))
+	// Output:
+	// {"type":"Feature","geometry":{"type":"Point","coordinates":[8.5417,47.3769]},"properties":{"name":"Zurich"}}
+}
+
+func ExampleParseMeasurements() {
+	anchors := `{
+		"type": "FeatureCollection",
+		"features": [
+			{"type": "Feature", "geometry": {"type": "Point", "coordinates": [8.5364, 47.4133]}, "properties": {"distance": 500}},
+			{"type": "Feature", "geometry": {"type": "Point", "coordinates": [8.5400, 47.4100]}, "properties": {"distance": 300, "weight": 2}}
+		]
+	}`
+
+	measurements, err := geojson.ParseMeasurements([]byte(anchors))
+	if err != nil {
+		panic(err)
+	}
+	for _, m := range measurements {
+		fmt.Printf("%.4f, %.4f: %.0f m (weight %.0f)\n", m.Lat, m.Lon, m.Distance, m.Weight)
+	}
+	// Output:
+	// 47.4133, 8.5364: 500 m (weight 1)
+	// 47.4100, 8.5400: 300 m (weight 2)
+}
+
+func ExampleEstimateCollection() {
+	measurements := trilateration.Measurements{
+		{Lat: 47.4133, Lon: 8.5364, Distance: 500, Weight: 1},
+		{Lat: 47.4100, Lon: 8.5400, Distance: 300, Weight: 1},
+		{Lat: 47.4120, Lon: 8.5450, Distance: 400, Weight: 1},
+	}
+	position, accuracy, err := trilateration.NewTrilaterator().Trilaterate(measurements)
+	if err != nil {
+		panic(err)
+	}
+
+	fc := geojson.EstimateCollection(position, accuracy)
+	for _, f := range fc.Features {
+		fmt.Println(f.Geometry.Type)
+	}
+	// Output:
+	// Point
+	// Polygon
+}
@@ -0,0 +1,328 @@
+package geojson
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"math"
+
+	"github.com/ethz-polymaps/polaris"
+	"github.com/ethz-polymaps/polaris/distance"
+)
+
+// GeoJSON object types used by this package.
+const (
+	TypePoint             = "Point"
+	TypePolygon           = "Polygon"
+	TypeMultiPolygon      = "MultiPolygon"
+	TypeFeature           = "Feature"
+	TypeFeatureCollection = "FeatureCollection"
+)
+
+// Geometry is a GeoJSON geometry object. [Point], [Polygon] and [Circle]
+// build geometries from positions and [Geometry.Position] reads a Point back.
+type Geometry struct {
+	// Type is the geometry type, for example "Point", "Polygon" or
+	// "MultiPolygon".
+	Type string `json:"type"`
+	// Coordinates are the coordinates of the geometry in any value that
+	// encodes to the GeoJSON form, which lists longitude before latitude.
+	// Decoded geometries hold a [json.RawMessage].
+	Coordinates any `json:"coordinates"`
+}
+
+// UnmarshalJSON implements [json.Unmarshaler]. It keeps the coordinates as
+// a [json.RawMessage] so that any geometry type can be decoded.
+func (g *Geometry) UnmarshalJSON(data []byte) error {
+	var v struct {
+		Type        string          `json:"type"`
+		Coordinates json.RawMessage `json:"coordinates"`
+	}
+	if err := json.Unmarshal(data, &v); err != nil {
+		return err
+	}
+	g.Type, g.Coordinates = v.Type, v.Coordinates
+	return nil
+}
+
+// Point returns a Point geometry for the position.
+func Point(p polaris.Position) Geometry {
+	return Geometry{Type: TypePoint, Coordinates: coordinates(p)}
+}
+
+// Point3D returns a Point geometry for the position with its altitude in
+// meters as the third coordinate.
+func Point3D(p polaris.Position3D) Geometry {
+	return Geometry{Type: TypePoint, Coordinates: []float64{p.Longitude, p.Latitude, p.Altitude}}
+}
+
+// Polygon returns a Polygon geometry with the given linear rings. The first
+// ring is the exterior, the others are holes. Rings are closed automatically
+// if their last position differs from the first. Following RFC 7946, the
+// exterior ring should be counterclockwise and holes clockwise.
+func Polygon(rings ...[]polaris.Position) Geometry {
+	return Geometry{Type: TypePolygon, Coordinates: polygonCoordinates(rings)}
+}
+
+// polygonCoordinates returns the coordinates of a Polygon with the given
+// rings, closing them if needed.
+func polygonCoordinates(rings [][]polaris.Position) [][][]float64 {
+	coords := make([][][]float64, len(rings))
+	for i, ring := range rings {
+		r := make([][]float64, 0, len(ring)+1)
+		for _, p := range ring {
+			r = append(r, coordinates(p))
+		}
+		if len(ring) > 0 && ring[0] != ring[len(ring)-1] {
+			r = append(r, coordinates(ring[0]))
+		}
+		coords[i] = r
+	}
+	return coords
+}
+
+// Circle returns the circle of the given radius in meters around center on
+// the WGS-84 ellipsoid, approximated by a ring with the given number of
+// segments, at least 3. The ring runs counterclockwise as required by
+// RFC 7946.
+//
+// The result is a Polygon, unless the circle crosses the antimeridian. It is
+// then cut there into a MultiPolygon of two Polygons, so that all longitudes
+// stay within ±180 as RFC 7946 recommends. A circle around a pole remains a
+// Polygon whose ring follows the antimeridian up to the pole.
+func Circle(center polaris.Position, radius float64, segments int) Geometry {
+	if segments < 3 {
+		segments = 3
+	}
+	// Longitudes are unwrapped along the ring, so that they only leave ±180
+	// after the ring has crossed the antimeridian.
+	ring := make([]polaris.Position, segments)
+	for i := range ring {
+		// Decreasing bearings run counterclockwise.
+		bearing := 360 - float64(i)*360/float64(segments)
+		p, _ := distance.VincentyDestination(center, bearing, radius)
+		if i > 0 {
+			prev := ring[i-1].Longitude
+			p.Longitude = prev + math.Remainder(p.Longitude-prev, 360)
+		}
+		ring[i] = p
+	}
+
+	// A ring around a pole winds once around the Earth, any other ring not
+	// at all.
+	last := ring[len(ring)-1].Longitude
+	winding := last + math.Remainder(ring[0].Longitude-last, 360) - ring[0].Longitude
+	if math.Abs(winding) > 180 {
+		return Polygon(aroundPole(ring, math.Copysign(1, winding)))
+	}
+
+	west, east := ring[0].Longitude, ring[0].Longitude
+	for _, p := range ring {
+		west, east = math.Min(west, p.Longitude), math.Max(east, p.Longitude)
+	}
+	var cut float64
+	switch {
+	case east > 180:
+		cut = 180
+	case west < -180:
+		cut = -180
+	default:
+		return Polygon(ring)
+	}
+	inside := clipRing(ring, cut, cut < 0)
+	outside := clipRing(ring, cut, cut > 0)
+	for i := range outside {
+		outside[i].Longitude -= 2 * cut
+	}
+	return Geometry{Type: TypeMultiPolygon, Coordinates: [][][][]float64{
+		polygonCoordinates([][]polaris.Position{inside}),
+		polygonCoordinates([][]polaris.Position{outside}),
+	}}
+}
+
+// clipRing returns the part of the closed ring east of the meridian at lon if
+// east is true, or west of it otherwise. Edges crossing the meridian are cut
+// where they meet it.
+func clipRing(ring []polaris.Position, lon float64, east bool) []polaris.Position {
+	keep := func(p polaris.Position) bool {
+		if east {
+			return p.Longitude >= lon
+		}
+		return p.Longitude <= lon
+	}
+	var clipped []polaris.Position
+	for i, p := range ring {
+		q := ring[(i+1)%len(ring)]
+		if keep(p) {
+			clipped = append(clipped, p)
+		}
+		if keep(p) != keep(q) {
+			clipped = append(clipped, crossing(p, q, lon))
+		}
+	}
+	return clipped
+}
+
+// aroundPole turns a ring with unwrapped longitudes that winds once around a
+// pole, eastwards for direction 1 and westwards for -1, into a ring within
+// ±180 that runs along the antimeridian to the pole and back. A
+// counterclockwise ring winds eastwards around the North Pole and westwards
+// around the South Pole.
+func aroundPole(ring []polaris.Position, direction float64) []polaris.Position {
+	n := len(ring)
+	normalized := make([]polaris.Position, n)
+	start := 0
+	for i, p := range ring {
+		p.Longitude = math.Remainder(p.Longitude, 360)
+		normalized[i] = p
+		if i > 0 && math.Abs(p.Longitude-normalized[i-1].Longitude) > 180 {
+			start = i
+		}
+	}
+	// Start after the edge that crosses the antimeridian.
+	normalized = append(normalized[start:], normalized[:start]...)
+
+	first, last := normalized[0], normalized[n-1]
+	first.Longitude += 360 * direction
+	lat := crossing(last, first, 180*direction).Latitude
+	pole := 90 * direction
+
+	rotated := make([]polaris.Position, 0, n+4)
+	rotated = append(rotated, polaris.NewPosition(lat, -180*direction))
+	rotated = append(rotated, normalized...)
+	return append(rotated,
+		polaris.NewPosition(lat, 180*direction),
+		polaris.NewPosition(pole, 180*direction),
+		polaris.NewPosition(pole, -180*direction),
+	)
+}
+
+// crossing returns the point where the edge from p to q meets the meridian at
+// lon, interpolating the latitude linearly in longitude.
+func crossing(p, q polaris.Position, lon float64) polaris.Position {
+	t := (lon - p.Longitude) / (q.Longitude - p.Longitude)
+	return polaris.NewPosition(p.Latitude+t*(q.Latitude-p.Latitude), lon)
+}
+
+// Position returns the position of a Point geometry. An optional altitude is
+// ignored; use [Geometry.Position3D] to read it.
+func (g Geometry) Position() (polaris.Position, error) {
+	p, err := g.Position3D()
+	if err != nil {
+		return polaris.EmptyPosition, err
+	}
+	return p.Position, nil
+}
+
+// Position3D returns the position of a Point geometry together with its
+// altitude in meters. A Point without a third coordinate has altitude 0.
+func (g Geometry) Position3D() (polaris.Position3D, error) {
+	if g.Type != TypePoint {
+		return polaris.Position3D{}, fmt.Errorf("geometry type %q is not a Point", g.Type)
+	}
+	data, err := json.Marshal(g.Coordinates)
+	if err != nil {
+		return polaris.Position3D{}, fmt.Errorf("point coordinates: %w", err)
+	}
+	var coords []float64
+	if err := json.Unmarshal(data, &coords); err != nil {
+		return polaris.Position3D{}, fmt.Errorf("point coordinates: %w", err)
+	}
+	if len(coords) != 2 && len(coords) != 3 {
+		return polaris.Position3D{}, fmt.Errorf("point must have 2 or 3 coordinates, got %d", len(coords))
+	}
+	p := polaris.Position3D{Position: polaris.NewPosition(coords[1], coords[0])}
+	if len(coords) == 3 {
+		p.Altitude = coords[2]
+	}
+	if err := p.Validate(); err != nil {
+		return polaris.Position3D{}, err
+	}
+	return p, nil
+}
+
+// Feature is a GeoJSON Feature: a geometry with properties.
+type Feature struct {
+	Geometry   Geometry
+	Properties map[string]any
+}
+
+// NewFeature returns a Feature with the given geometry and properties.
+func NewFeature(g Geometry, properties map[string]any) Feature {
+	return Feature{Geometry: g, Properties: properties}
+}
+
+// featureJSON is the JSON representation of a Feature.
+type featureJSON struct {
+	Type       string         `json:"type"`
+	Geometry   *Geometry      `json:"geometry"`
+	Properties map[string]any `json:"properties"`
+}
+
+// MarshalJSON implements [json.Marshaler] and sets the type member to
+// "Feature".
+func (f Feature) MarshalJSON() ([]byte, error) {
+	return json.Marshal(featureJSON{Type: TypeFeature, Geometry: &f.Geometry, Properties: f.Properties})
+}
+
+// UnmarshalJSON implements [json.Unmarshaler]. It requires the type member
+// to be "Feature" and a non-null geometry.
+func (f *Feature) UnmarshalJSON(data []byte) error {
+	var v featureJSON
+	if err := json.Unmarshal(data, &v); err != nil {
+		return err
+	}
+	if v.Type != TypeFeature {
+		return fmt.Errorf("object type %q is not a Feature", v.Type)
+	}
+	if v.Geometry == nil {
+		return errors.New("feature has no geometry")
+	}
+	f.Geometry, f.Properties = *v.Geometry, v.Properties
+	return nil
+}
+
+// FeatureCollection is a GeoJSON FeatureCollection.
+type FeatureCollection struct {
+	Features []Feature
+}
+
+// NewFeatureCollection returns a FeatureCollection of the given features.
+func NewFeatureCollection(features ...Feature) FeatureCollection {
+	return FeatureCollection{Features: features}
+}
+
+// featureCollectionJSON is the JSON representation of a FeatureCollection.
+type featureCollectionJSON struct {
+	Type     string    `json:"type"`
+	Features []Feature `json:"features"`
+}
+
+// MarshalJSON implements [json.Marshaler] and sets the type member to
+// "FeatureCollection".
+func (fc FeatureCollection) MarshalJSON() ([]byte, error) {
+	features := fc.Features
+	if features == nil {
+		features = []Feature{}
+	}
+	return json.Marshal(featureCollectionJSON{Type: TypeFeatureCollection, Features: features})
+}
+
+// UnmarshalJSON implements [json.Unmarshaler]. It requires the type member
+// to be "FeatureCollection".
+func (fc *FeatureCollection) UnmarshalJSON(data []byte) error {
+	var v featureCollectionJSON
+	if err := json.Unmarshal(data, &v); err != nil {
+		return err
+	}
+	if v.Type != TypeFeatureCollection {
+		return fmt.Errorf("object type %q is not a FeatureCollection", v.Type)
+	}
+	fc.Features = v.Features
+	return nil
+}
+
+// coordinates returns the GeoJSON position of p: longitude before latitude.
+func coordinates(p polaris.Position) []float64 {
+	return []float64{p.Longitude, p.Latitude}
+}
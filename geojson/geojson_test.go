@@ -0,0 +1,187 @@
+package geojson
+
+import (
+	"encoding/json"
+	"math"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/ethz-polymaps/polaris"
+	"github.com/ethz-polymaps/polaris/distance"
+)
+
+func TestPoint(t *testing.T) {
+	data, err := json.Marshal(Point(polaris.NewPosition(47.3769, 8.5417)))
+	require.NoError(t, err)
+	assert.JSONEq(t, `{"type":"Point","coordinates":[8.5417,47.3769]}`, string(data))
+
+	_, err = json.Marshal(Point(polaris.NewPosition(math.NaN(), 0)))
+	assert.Error(t, err)
+}
+
+func TestPolygon(t *testing.T) {
+	square := []polaris.Position{
+		polaris.NewPosition(0, 0),
+		polaris.NewPosition(0, 1),
+		polaris.NewPosition(1, 1),
+		polaris.NewPosition(1, 0),
+	}
+	want := `{"type":"Polygon","coordinates":[[[0,0],[1,0],[1,1],[0,1],[0,0]]]}`
+
+	data, err := json.Marshal(Polygon(square))
+	require.NoError(t, err)
+	assert.JSONEq(t, want, string(data))
+
+	// Closed rings are not closed again.
+	data, err = json.Marshal(Polygon(append(square, square[0])))
+	require.NoError(t, err)
+	assert.JSONEq(t, want, string(data))
+}
+
+func TestCircle(t *testing.T) {
+	tests := []struct {
+		name     string
+		center   polaris.Position
+		radius   float64
+		wantType string
+		polygons int
+	}{
+		{name: "zurich", center: polaris.NewPosition(47.3769, 8.5417), radius: 250, wantType: TypePolygon, polygons: 1},
+		{name: "antimeridian", center: polaris.NewPosition(-16.5, 179.999), radius: 5000, wantType: TypeMultiPolygon, polygons: 2},
+		{name: "antimeridian west", center: polaris.NewPosition(65.6, -179.99), radius: 20000, wantType: TypeMultiPolygon, polygons: 2},
+		{name: "north pole", center: polaris.NewPosition(89.9, 8.5), radius: 50000, wantType: TypePolygon, polygons: 1},
+		{name: "south pole", center: polaris.NewPosition(-89.5, -120), radius: 100000, wantType: TypePolygon, polygons: 1},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			g := Circle(tt.center, tt.radius, 16)
+			require.Equal(t, tt.wantType, g.Type)
+
+			var polygons [][][][]float64
+			if g.Type == TypeMultiPolygon {
+				polygons = g.Coordinates.([][][][]float64)
+			} else {
+				polygons = [][][][]float64{g.Coordinates.([][][]float64)}
+			}
+			require.Len(t, polygons, tt.polygons)
+
+			var vertices int
+			for _, rings := range polygons {
+				require.Len(t, rings, 1)
+				ring := rings[0]
+				assert.Equal(t, ring[0], ring[len(ring)-1])
+
+				var signedArea float64
+				for i, c := range ring[:len(ring)-1] {
+					assert.LessOrEqual(t, math.Abs(c[0]), 180.0)
+					// Vertices off the antimeridian lie on the circle.
+					if math.Abs(c[0]) != 180 {
+						vertices++
+						p := polaris.NewPosition(c[1], c[0])
+						assert.InDelta(t, tt.radius, distance.VincentyDistance(tt.center, p), 1e-6)
+					}
+
+					next := ring[i+1]
+					signedArea += c[0]*next[1] - next[0]*c[1]
+				}
+				assert.Positive(t, signedArea, "ring must be counterclockwise")
+			}
+			assert.Equal(t, 16, vertices)
+		})
+	}
+
+	assert.Len(t, Circle(polaris.NewPosition(0, 0), 10, 1).Coordinates.([][][]float64)[0], 4)
+}
+
+func TestGeometry_Position(t *testing.T) {
+	tests := []struct {
+		name    string
+		input   string
+		want    polaris.Position
+		wantErr bool
+	}{
+		{name: "point", input: `{"type":"Point","coordinates":[8.5417,47.3769]}`, want: polaris.NewPosition(47.3769, 8.5417)},
+		{name: "point with altitude", input: `{"type":"Point","coordinates":[8.5417,47.3769,408]}`, want: polaris.NewPosition(47.3769, 8.5417)},
+		{name: "polygon", input: `{"type":"Polygon","coordinates":[[[0,0],[1,0],[1,1],[0,0]]]}`, wantErr: true},
+		{name: "one coordinate", input: `{"type":"Point","coordinates":[8.5417]}`, wantErr: true},
+		{name: "invalid latitude", input: `{"type":"Point","coordinates":[8.5417,97.3769]}`, wantErr: true},
+		{name: "not numbers", input: `{"type":"Point","coordinates":["8.5417","47.3769"]}`, wantErr: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var g Geometry
+			require.NoError(t, json.Unmarshal([]byte(tt.input), &g))
+
+			got, err := g.Position()
+			if tt.wantErr {
+				assert.Error(t, err)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, tt.want, got)
+		})
+	}
+
+	// Geometries built in memory can be read without encoding.
+	got, err := Point(polaris.NewPosition(1, 2)).Position()
+	require.NoError(t, err)
+	assert.Equal(t, polaris.NewPosition(1, 2), got)
+}
+
+func TestGeometry_Position3D(t *testing.T) {
+	tests := []struct {
+		name    string
+		input   string
+		want    polaris.Position3D
+		wantErr bool
+	}{
+		{name: "point with altitude", input: `{"type":"Point","coordinates":[8.5417,47.3769,408.5]}`, want: polaris.NewPosition3D(47.3769, 8.5417, 408.5)},
+		{name: "point without altitude", input: `{"type":"Point","coordinates":[8.5417,47.3769]}`, want: polaris.NewPosition3D(47.3769, 8.5417, 0)},
+		{name: "four coordinates", input: `{"type":"Point","coordinates":[8.5417,47.3769,408,1]}`, wantErr: true},
+		{name: "invalid longitude", input: `{"type":"Point","coordinates":[188.5417,47.3769,408]}`, wantErr: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var g Geometry
+			require.NoError(t, json.Unmarshal([]byte(tt.input), &g))
+
+			got, err := g.Position3D()
+			if tt.wantErr {
+				assert.Error(t, err)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, tt.want, got)
+		})
+	}
+
+	data, err := json.Marshal(Point3D(polaris.NewPosition3D(47.3769, 8.5417, 408.5)))
+	require.NoError(t, err)
+	assert.JSONEq(t, `{"type":"Point","coordinates":[8.5417,47.3769,408.5]}`, string(data))
+}
+
+func TestFeature_JSON(t *testing.T) {
+	f := NewFeature(Point(polaris.NewPosition(1, 2)), map[string]any{"name": "a"})
+	data, err := json.Marshal(f)
+	require.NoError(t, err)
+	assert.JSONEq(t, `{"type":"Feature","geometry":{"type":"Point","coordinates":[2,1]},"properties":{"name":"a"}}`, string(data))
+
+	var got Feature
+	require.NoError(t, json.Unmarshal(data, &got))
+	assert.Equal(t, TypePoint, got.Geometry.Type)
+	assert.Equal(t, "a", got.Properties["name"])
+
+	assert.Error(t, json.Unmarshal([]byte(`{"type":"Point","coordinates":[2,1]}`), &got))
+	assert.Error(t, json.Unmarshal([]byte(`{"type":"Feature","geometry":null,"properties":null}`), &got))
+}
+
+func TestFeatureCollection_JSON(t *testing.T) {
+	data, err := json.Marshal(FeatureCollection{})
+	require.NoError(t, err)
+	assert.JSONEq(t, `{"type":"FeatureCollection","features":[]}`, string(data))
+
+	var fc FeatureCollection
+	assert.Error(t, json.Unmarshal([]byte(`{"type":"Feature","features":[]}`), &fc))
+}
@@ -0,0 +1,111 @@
+package geojson
+
+import (
+	"encoding/json"
+	"fmt"
+
+	"github.com/ethz-polymaps/polaris"
+	"github.com/ethz-polymaps/polaris/trilateration"
+)
+
+// Property names used for measurements and estimates.
+const (
+	PropertyDistance = "distance"
+	PropertyWeight   = "weight"
+	PropertyAccuracy = "accuracy"
+)
+
+// CircleSegments is the number of segments of the accuracy circle written by
+// [EstimateCollection].
+const CircleSegments = 64
+
+// MeasurementFeature returns a Point feature at the anchor of the measurement
+// with its distance and weight as properties. A non-zero altitude of the
+// anchor is written as the third coordinate.
+func MeasurementFeature(m trilateration.Measurement) Feature {
+	g := Point(polaris.NewPosition(m.Lat, m.Lon))
+	if m.Alt != 0 {
+		g = Point3D(polaris.NewPosition3D(m.Lat, m.Lon, m.Alt))
+	}
+	return NewFeature(g, map[string]any{
+		PropertyDistance: m.Distance,
+		PropertyWeight:   m.Weight,
+	})
+}
+
+// MeasurementCollection returns a FeatureCollection with a
+// [MeasurementFeature] for each measurement.
+func MeasurementCollection(measurements trilateration.Measurements) FeatureCollection {
+	features := make([]Feature, len(measurements))
+	for i, m := range measurements {
+		features[i] = MeasurementFeature(m)
+	}
+	return NewFeatureCollection(features...)
+}
+
+// EstimateCollection returns a FeatureCollection describing a trilateration
+// estimate: a Point feature at the position and a Polygon feature with the
+// circle of the accuracy radius in meters around it, which is a MultiPolygon
+// across the antimeridian as described for [Circle]. Both carry the accuracy
+// as a property.
+func EstimateCollection(p polaris.Position, accuracy float64) FeatureCollection {
+	properties := map[string]any{PropertyAccuracy: accuracy}
+	return NewFeatureCollection(
+		NewFeature(Point(p), properties),
+		NewFeature(Circle(p, accuracy, CircleSegments), properties),
+	)
+}
+
+// Measurements reads anchor features back into measurements. Every feature
+// must be a Point with a numeric distance property. The weight property is
+// optional and defaults to 1, and the altitude of the anchor is read from an
+// optional third coordinate.
+func (fc FeatureCollection) Measurements() (trilateration.Measurements, error) {
+	measurements := make(trilateration.Measurements, len(fc.Features))
+	for i, f := range fc.Features {
+		p, err := f.Geometry.Position3D()
+		if err != nil {
+			return nil, fmt.Errorf("feature %d: %w", i, err)
+		}
+		d, err := numberProperty(f.Properties, PropertyDistance)
+		if err != nil {
+			return nil, fmt.Errorf("feature %d: %w", i, err)
+		}
+		w := 1.0
+		if _, ok := f.Properties[PropertyWeight]; ok {
+			if w, err = numberProperty(f.Properties, PropertyWeight); err != nil {
+				return nil, fmt.Errorf("feature %d: %w", i, err)
+			}
+		}
+		measurements[i] = trilateration.Measurement{Lat: p.Latitude, Lon: p.Longitude, Alt: p.Altitude, Distance: d, Weight: w}
+	}
+	return measurements, nil
+}
+
+// ParseMeasurements decodes a GeoJSON FeatureCollection of anchors and
+// returns its measurements as described by [FeatureCollection.Measurements].
+func ParseMeasurements(data []byte) (trilateration.Measurements, error) {
+	var fc FeatureCollection
+	if err := json.Unmarshal(data, &fc); err != nil {
+		return nil, err
+	}
+	return fc.Measurements()
+}
+
+// numberProperty returns the named property as a number.
+func numberProperty(properties map[string]any, name string) (float64, error) {
+	v, ok := properties[name]
+	if !ok {
+		return 0, fmt.Errorf("missing property %q", name)
+	}
+	switch n := v.(type) {
+	case float64:
+		return n, nil
+	case int:
+		return float64(n), nil
+	case json.Number:
+		return n.Float64()
+	default:
+		return 0, fmt.Errorf("property %q is %T, not a number", name, v)
+	}
+}
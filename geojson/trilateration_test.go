@@ -0,0 +1,140 @@
+package geojson
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/ethz-polymaps/polaris"
+	"github.com/ethz-polymaps/polaris/trilateration"
+)
+
+func TestMeasurementCollection(t *testing.T) {
+	measurements := trilateration.Measurements{
+		{Lat: 47.4133, Lon: 8.5364, Distance: 500, Weight: 1},
+		{Lat: 47.41, Lon: 8.54, Distance: 300.5, Weight: 0.25},
+	}
+
+	data, err := json.Marshal(MeasurementCollection(measurements))
+	require.NoError(t, err)
+	assert.JSONEq(t, `{
+		"type": "FeatureCollection",
+		"features": [
+			{"type": "Feature", "geometry": {"type": "Point", "coordinates": [8.5364, 47.4133]}, "properties": {"distance": 500, "weight": 1}},
+			{"type": "Feature", "geometry": {"type": "Point", "coordinates": [8.54, 47.41]}, "properties": {"distance": 300.5, "weight": 0.25}}
+		]
+	}`, string(data))
+
+	got, err := ParseMeasurements(data)
+	require.NoError(t, err)
+	assert.Equal(t, measurements, got)
+}
+
+func TestMeasurementCollection_altitude(t *testing.T) {
+	measurements := trilateration.Measurements{
+		{Lat: 47.4133, Lon: 8.5364, Alt: 413.5, Distance: 7.2, Weight: 1},
+		{Lat: 47.4132, Lon: 8.5364, Alt: -2.25, Distance: 6.9, Weight: 1},
+		{Lat: 47.4133, Lon: 8.5365, Distance: 7.1, Weight: 1},
+	}
+
+	data, err := json.Marshal(MeasurementCollection(measurements))
+	require.NoError(t, err)
+	assert.JSONEq(t, `{
+		"type": "FeatureCollection",
+		"features": [
+			{"type": "Feature", "geometry": {"type": "Point", "coordinates": [8.5364, 47.4133, 413.5]}, "properties": {"distance": 7.2, "weight": 1}},
+			{"type": "Feature", "geometry": {"type": "Point", "coordinates": [8.5364, 47.4132, -2.25]}, "properties": {"distance": 6.9, "weight": 1}},
+			{"type": "Feature", "geometry": {"type": "Point", "coordinates": [8.5365, 47.4133]}, "properties": {"distance": 7.1, "weight": 1}}
+		]
+	}`, string(data))
+
+	got, err := ParseMeasurements(data)
+	require.NoError(t, err)
+	assert.Equal(t, measurements, got)
+}
+
+func TestParseMeasurements(t *testing.T) {
+	tests := []struct {
+		name    string
+		input   string
+		want    trilateration.Measurements
+		wantErr string
+	}{
+		{
+			name:  "default weight and altitude",
+			input: `{"type":"FeatureCollection","features":[{"type":"Feature","geometry":{"type":"Point","coordinates":[8.5,47.4,410]},"properties":{"distance":12.5,"name":"anchor-1"}}]}`,
+			want:  trilateration.Measurements{{Lat: 47.4, Lon: 8.5, Alt: 410, Distance: 12.5, Weight: 1}},
+		},
+		{
+			name:  "empty",
+			input: `{"type":"FeatureCollection","features":[]}`,
+			want:  trilateration.Measurements{},
+		},
+		{
+			name:    "missing distance",
+			input:   `{"type":"FeatureCollection","features":[{"type":"Feature","geometry":{"type":"Point","coordinates":[8.5,47.4]},"properties":{}}]}`,
+			wantErr: `feature 0: missing property "distance"`,
+		},
+		{
+			name:    "distance is a string",
+			input:   `{"type":"FeatureCollection","features":[{"type":"Feature","geometry":{"type":"Point","coordinates":[8.5,47.4]},"properties":{"distance":"12"}}]}`,
+			wantErr: `property "distance" is string`,
+		},
+		{
+			name:    "weight is null",
+			input:   `{"type":"FeatureCollection","features":[{"type":"Feature","geometry":{"type":"Point","coordinates":[8.5,47.4]},"properties":{"distance":12,"weight":null}}]}`,
+			wantErr: `property "weight"`,
+		},
+		{
+			name:    "polygon",
+			input:   `{"type":"FeatureCollection","features":[{"type":"Feature","geometry":{"type":"Polygon","coordinates":[]},"properties":{"distance":12}}]}`,
+			wantErr: "not a Point",
+		},
+		{
+			name:    "not a collection",
+			input:   `{"type":"Feature","geometry":{"type":"Point","coordinates":[8.5,47.4]},"properties":{"distance":12}}`,
+			wantErr: "not a FeatureCollection",
+		},
+		{
+			name:    "malformed",
+			input:   `{"type":`,
+			wantErr: "unexpected end",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := ParseMeasurements([]byte(tt.input))
+			if tt.wantErr != "" {
+				require.Error(t, err)
+				assert.Contains(t, err.Error(), tt.wantErr)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
+
+func TestEstimateCollection(t *testing.T) {
+	p := polaris.NewPosition(47.3769, 8.5417)
+	fc := EstimateCollection(p, 12.5)
+	require.Len(t, fc.Features, 2)
+
+	point, polygon := fc.Features[0], fc.Features[1]
+	assert.Equal(t, TypePoint, point.Geometry.Type)
+	assert.Equal(t, 12.5, point.Properties[PropertyAccuracy])
+	assert.Equal(t, TypePolygon, polygon.Geometry.Type)
+	assert.Equal(t, 12.5, polygon.Properties[PropertyAccuracy])
+	assert.Len(t, polygon.Geometry.Coordinates.([][][]float64)[0], CircleSegments+1)
+
+	data, err := json.Marshal(fc)
+	require.NoError(t, err)
+
+	var decoded FeatureCollection
+	require.NoError(t, json.Unmarshal(data, &decoded))
+	got, err := decoded.Features[0].Geometry.Position()
+	require.NoError(t, err)
+	assert.Equal(t, p, got)
+}
@@ -0,0 +1,26 @@
+// Package geometry provides polygons on the Earth's surface, such as rooms,
+// buildings and campuses, with point-in-polygon tests, areas and perimeters.
+//
+// # Rings and Polygons
+//
+// A [Ring] is a closed path of positions and a [Polygon] is an outer ring
+// with optional holes, for example a building around a courtyard:
+//
+//	building, err := geometry.NewPolygon(walls, courtyard)
+//	if building.Contains(estimate) {
+//	    // ...
+//	}
+//
+// [Polygon.Contains] treats the edges as great-circle arcs and works for
+// polygons across the antimeridian and around the poles. Positions on the
+// boundary belong to the polygon.
+//
+// # Area and Perimeter
+//
+// [Polygon.Area] returns the area on the WGS-84 ellipsoid in square meters
+// using [distance.KarneyArea]. [Polygon.Perimeter] measures the outer ring
+// and the holes with any distance function of the distance package:
+//
+//	area := building.Area()
+//	perimeter := building.Perimeter(distance.KarneyDistance)
+package geometry
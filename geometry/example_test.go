@@ -0,0 +1,67 @@
+package geometry_test
+
+import (
+	"fmt"
+
+	"github.com/ethz-polymaps/polaris"
+	"github.com/ethz-polymaps/polaris/distance"
+	"github.com/ethz-polymaps/polaris/geometry"
+)
+
+func ExamplePolygon_Contains() {
+	building, err := geometry.NewPolygon(
+		[]polaris.Position{
+			polaris.NewPosition(46.999, 7.999),
+			polaris.NewPosition(46.999, 8.001),
+			polaris.NewPosition(47.001, 8.001),
+			polaris.NewPosition(47.001, 7.999),
+		},
+		[]polaris.Position{
+			polaris.NewPosition(46.9995, 7.9995),
+			polaris.NewPosition(47.0005, 7.9995),
+			polaris.NewPosition(47.0005, 8.0005),
+			polaris.NewPosition(46.9995, 8.0005),
+		},
+	)
+	if err != nil {
+		panic(err)
+	}
+	fmt.Println(building.Contains(polaris.NewPosition(46.9992, 8.0)))
+	fmt.Println(building.Contains(polaris.NewPosition(47.0, 8.0)))
+	// Output:
+	// true
+	// false
+}
+
+func ExamplePolygon_Area() {
+	building := geometry.Polygon{
+		Outer: geometry.Ring{
+			polaris.NewPosition(46.999, 7.999),
+			polaris.NewPosition(46.999, 8.001),
+			polaris.NewPosition(47.001, 8.001),
+			polaris.NewPosition(47.001, 7.999),
+		},
+	}
+	fmt.Printf("%.0f m²\n", building.Area())
+	fmt.Printf("%.1f m\n", building.Perimeter(distance.KarneyDistance))
+	// Output:
+	// 33821 m²
+	// 748.9 m
+}
+
+func ExampleRing_Contains() {
+	// A ring along 80°N contains the north pole.
+	arctic := geometry.Ring{
+		polaris.NewPosition(80, 0),
+		polaris.NewPosition(80, 90),
+		polaris.NewPosition(80, 180),
+		polaris.NewPosition(80, -90),
+	}
+	fmt.Println(arctic.Contains(polaris.NewPosition(90, 0)))
+	fmt.Println(arctic.Contains(polaris.NewPosition(85, -170)))
+	fmt.Println(arctic.Contains(polaris.NewPosition(60, 10)))
+	// Output:
+	// true
+	// true
+	// false
+}
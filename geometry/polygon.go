@@ -0,0 +1,76 @@
+package geometry
+
+import (
+	"fmt"
+
+	"github.com/ethz-polymaps/polaris"
+)
+
+// Polygon is an area bounded by an outer ring, from which the areas of its
+// holes are removed. The holes must lie inside the outer ring and must not
+// overlap each other.
+type Polygon struct {
+	Outer Ring
+	Holes []Ring
+}
+
+// NewPolygon returns a polygon with the given outer ring and holes, each
+// checked by [NewRing].
+func NewPolygon(outer []polaris.Position, holes ...[]polaris.Position) (Polygon, error) {
+	o, err := NewRing(outer)
+	if err != nil {
+		return Polygon{}, fmt.Errorf("outer ring: %w", err)
+	}
+	p := Polygon{Outer: o}
+	for i, h := range holes {
+		r, err := NewRing(h)
+		if err != nil {
+			return Polygon{}, fmt.Errorf("hole %d: %w", i, err)
+		}
+		p.Holes = append(p.Holes, r)
+	}
+	return p, nil
+}
+
+// Contains reports whether p lies inside the polygon. Positions on the
+// boundary of the outer ring or of a hole belong to the polygon. See
+// [Ring.Contains] for how rings around a pole are treated.
+func (g Polygon) Contains(p polaris.Position) bool {
+	inside, boundary := g.Outer.locate(p)
+	if boundary {
+		return true
+	}
+	if !inside {
+		return false
+	}
+	for _, h := range g.Holes {
+		inside, boundary := h.locate(p)
+		if boundary {
+			return true
+		}
+		if inside {
+			return false
+		}
+	}
+	return true
+}
+
+// Area returns the area of the polygon on the WGS-84 ellipsoid in square
+// meters: the area of the outer ring minus those of the holes.
+func (g Polygon) Area() float64 {
+	area := g.Outer.Area()
+	for _, h := range g.Holes {
+		area -= h.Area()
+	}
+	return area
+}
+
+// Perimeter returns the total length in meters of the outer ring and the
+// holes, measured with the given distance function.
+func (g Polygon) Perimeter(distanceFunc func(a, b polaris.Position) float64) float64 {
+	perimeter := g.Outer.Perimeter(distanceFunc)
+	for _, h := range g.Holes {
+		perimeter += h.Perimeter(distanceFunc)
+	}
+	return perimeter
+}
@@ -0,0 +1,87 @@
+package geometry
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/ethz-polymaps/polaris"
+	"github.com/ethz-polymaps/polaris/distance"
+)
+
+// building is a square of 0.002° around (47.0, 8.0) with a square courtyard
+// of 0.001°.
+var building = Polygon{
+	Outer: ring(46.999, 7.999, 46.999, 8.001, 47.001, 8.001, 47.001, 7.999),
+	Holes: []Ring{ring(46.9995, 7.9995, 47.0005, 7.9995, 47.0005, 8.0005, 46.9995, 8.0005)},
+}
+
+func TestNewPolygon(t *testing.T) {
+	t.Run("valid", func(t *testing.T) {
+		got, err := NewPolygon(building.Outer, building.Holes[0])
+		require.NoError(t, err)
+		assert.Equal(t, building, got)
+	})
+
+	t.Run("invalid outer ring", func(t *testing.T) {
+		_, err := NewPolygon(ring(0, 0, 1, 1))
+		assert.EqualError(t, err, "outer ring: ring needs at least 3 positions")
+	})
+
+	t.Run("invalid hole", func(t *testing.T) {
+		_, err := NewPolygon(building.Outer, building.Holes[0], ring(47, 8, 47, 200, 47.1, 8))
+		require.Error(t, err)
+		assert.ErrorIs(t, err, polaris.ErrInvalidLongitude)
+		assert.Contains(t, err.Error(), "hole 1: position 1:")
+	})
+}
+
+func TestPolygon_Contains(t *testing.T) {
+	capWithHole := Polygon{
+		Outer: ring(80, 0, 80, 120, 80, -120),
+		Holes: []Ring{ring(88, 170, 88, -170, 89, -170, 89, 170)},
+	}
+
+	tests := []struct {
+		name    string
+		polygon Polygon
+		p       polaris.Position
+		want    bool
+	}{
+		{"building", building, polaris.NewPosition(46.9992, 8.0), true},
+		{"courtyard", building, polaris.NewPosition(47.0, 8.0), false},
+		{"courtyard wall", building, polaris.NewPosition(47.0, 8.0005), true},
+		{"outer wall", building, polaris.NewPosition(47.0, 8.001), true},
+		{"outside", building, polaris.NewPosition(47.0, 8.002), false},
+		{"cap", capWithHole, polaris.NewPosition(85, 0), true},
+		{"cap at pole", capWithHole, polaris.NewPosition(90, 0), true},
+		{"cap hole across antimeridian", capWithHole, polaris.NewPosition(88.5, 180), false},
+		{"cap next to hole", capWithHole, polaris.NewPosition(88.5, -160), true},
+		{"below cap", capWithHole, polaris.NewPosition(70, 0), false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.want, tt.polygon.Contains(tt.p))
+		})
+	}
+}
+
+func TestPolygon_Area(t *testing.T) {
+	outer := Polygon{Outer: building.Outer}.Area()
+	courtyard := Polygon{Outer: building.Holes[0]}.Area()
+
+	// The courtyard has a quarter of the area of the building.
+	assert.InDelta(t, 4, outer/courtyard, 1e-6)
+	assert.InDelta(t, outer-courtyard, building.Area(), 1e-6)
+	// About 222 m by 152 m, minus the courtyard.
+	assert.InDelta(t, 25366, building.Area(), 1)
+}
+
+func TestPolygon_Perimeter(t *testing.T) {
+	outer := Polygon{Outer: building.Outer}.Perimeter(distance.KarneyDistance)
+	courtyard := Polygon{Outer: building.Holes[0]}.Perimeter(distance.KarneyDistance)
+
+	assert.InDelta(t, 2, outer/courtyard, 1e-6)
+	assert.InDelta(t, outer+courtyard, building.Perimeter(distance.KarneyDistance), 1e-6)
+}
@@ -0,0 +1,171 @@
+package geometry
+
+import (
+	"errors"
+	"fmt"
+	"math"
+
+	"github.com/ethz-polymaps/polaris"
+	"github.com/ethz-polymaps/polaris/distance"
+)
+
+// boundaryTolerance is the distance in degrees within which a position counts
+// as lying on the boundary of a ring, about 0.1 mm.
+const boundaryTolerance = 1e-9
+
+// Ring is a closed path of positions. The last position is connected back to
+// the first one, so it need not be repeated. Edges are the shortest paths
+// between successive positions, which may cross the antimeridian.
+type Ring []polaris.Position
+
+// NewRing returns a ring through the given positions. A last position equal
+// to the first one is dropped. It returns an error if a position fails
+// [polaris.Position.Validate] or fewer than three positions remain.
+func NewRing(positions []polaris.Position) (Ring, error) {
+	for i, p := range positions {
+		if err := p.Validate(); err != nil {
+			return nil, fmt.Errorf("position %d: %w", i, err)
+		}
+	}
+	if n := len(positions); n > 1 && positions[0] == positions[n-1] {
+		positions = positions[:n-1]
+	}
+	if len(positions) < 3 {
+		return nil, errors.New("ring needs at least 3 positions")
+	}
+	return append(Ring(nil), positions...), nil
+}
+
+// Contains reports whether p lies inside the ring or on its boundary. The
+// edges are great-circle arcs, which for rings the size of a building or a
+// campus coincide with the geodesics of [Ring.Area] to well below a
+// millimeter.
+//
+// The test does not depend on the orientation of the ring. A ring that winds
+// around a pole contains the pole on the side of its mean latitude, so a ring
+// along the parallel at 80°N contains the region north of it.
+func (r Ring) Contains(p polaris.Position) bool {
+	inside, boundary := r.locate(p)
+	return inside || boundary
+}
+
+// Area returns the area enclosed by the ring on the WGS-84 ellipsoid in
+// square meters, using [distance.KarneyArea]. The result is positive for
+// either orientation.
+func (r Ring) Area() float64 {
+	return math.Abs(distance.KarneyArea(r))
+}
+
+// Perimeter returns the length of the ring in meters, including the edge from
+// the last position back to the first, measured with the given distance
+// function such as [distance.KarneyDistance] or [distance.HaversineDistance].
+func (r Ring) Perimeter(distanceFunc func(a, b polaris.Position) float64) float64 {
+	if len(r) < 2 {
+		return 0
+	}
+	var sum float64
+	for i, a := range r {
+		sum += distanceFunc(a, r[(i+1)%len(r)])
+	}
+	return sum
+}
+
+// locate reports whether p lies in the interior of the ring and whether it
+// lies on its boundary.
+//
+// It counts the edges crossed by the meridian arc from p to the pole that the
+// ring does not enclose. The longitudes are taken relative to p, which makes
+// the antimeridian an ordinary meridian.
+func (r Ring) locate(p polaris.Position) (inside, boundary bool) {
+	if len(r) < 3 {
+		return false, false
+	}
+	pole, touched := r.pole()
+	if math.Abs(p.Latitude) >= 90 {
+		atPole := 1
+		if p.Latitude < 0 {
+			atPole = -1
+		}
+		return pole == atPole, touched == atPole
+	}
+
+	rayNorth := pole != 1 && touched != 1
+	crossings := 0
+	for i, a := range r {
+		b := r[(i+1)%len(r)]
+		lonA := math.Remainder(a.Longitude-p.Longitude, 360)
+		lonB := math.Remainder(b.Longitude-p.Longitude, 360)
+
+		if math.Abs(a.Latitude-p.Latitude) <= boundaryTolerance && math.Abs(lonA) <= boundaryTolerance {
+			return false, true
+		}
+
+		// Edges to a pole run along the meridian of their other end, and so
+		// do edges between positions of the same longitude.
+		meridian := math.NaN()
+		switch {
+		case math.Abs(a.Latitude) >= 90:
+			meridian = lonB
+		case math.Abs(b.Latitude) >= 90, lonA == lonB:
+			meridian = lonA
+		}
+		if !math.IsNaN(meridian) {
+			if math.Abs(meridian) <= boundaryTolerance &&
+				p.Latitude >= math.Min(a.Latitude, b.Latitude) && p.Latitude <= math.Max(a.Latitude, b.Latitude) {
+				return false, true
+			}
+			continue
+		}
+
+		// Edges spanning 180° or more cross the opposite meridian instead.
+		if math.Abs(lonB-lonA) >= 180 || (lonA > 0) == (lonB > 0) {
+			continue
+		}
+		lat := crossingLatitude(a.Latitude, lonA, b.Latitude, lonB)
+		if math.Abs(lat-p.Latitude) <= boundaryTolerance {
+			return false, true
+		}
+		if (lat > p.Latitude) == rayNorth {
+			crossings++
+		}
+	}
+	return crossings%2 == 1, false
+}
+
+// pole returns 1 if the ring winds around the north pole, -1 if it winds
+// around the south pole and 0 otherwise, together with the pole that one of
+// its positions lies on in the same encoding.
+func (r Ring) pole() (pole, touched int) {
+	var winding, latitudes float64
+	for i, a := range r {
+		b := r[(i+1)%len(r)]
+		latitudes += a.Latitude
+		switch {
+		case a.Latitude >= 90:
+			touched = 1
+		case a.Latitude <= -90:
+			touched = -1
+		}
+		if math.Abs(a.Latitude) >= 90 || math.Abs(b.Latitude) >= 90 {
+			continue
+		}
+		winding += math.Remainder(b.Longitude-a.Longitude, 360)
+	}
+	// The winding is 0 for ordinary rings and ±360 for rings around a pole.
+	if math.Abs(winding) > 180 {
+		pole = 1
+		if latitudes < 0 {
+			pole = -1
+		}
+	}
+	return pole, touched
+}
+
+// crossingLatitude returns the latitude at which the great circle through
+// (lat1, lon1) and (lat2, lon2) crosses the meridian at longitude 0. The
+// longitudes must differ by less than 180°.
+func crossingLatitude(lat1, lon1, lat2, lon2 float64) float64 {
+	const rad = math.Pi / 180
+	num := math.Tan(lat1*rad)*math.Sin(lon2*rad) - math.Tan(lat2*rad)*math.Sin(lon1*rad)
+	return math.Atan(num/math.Sin((lon2-lon1)*rad)) / rad
+}
@@ -0,0 +1,141 @@
+package geometry
+
+import (
+	"slices"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/ethz-polymaps/polaris"
+	"github.com/ethz-polymaps/polaris/distance"
+)
+
+// ring returns the ring through the given latitude and longitude pairs.
+func ring(coords ...float64) Ring {
+	r := make(Ring, 0, len(coords)/2)
+	for i := 0; i+1 < len(coords); i += 2 {
+		r = append(r, polaris.NewPosition(coords[i], coords[i+1]))
+	}
+	return r
+}
+
+func TestNewRing(t *testing.T) {
+	tests := []struct {
+		name      string
+		positions []polaris.Position
+		want      Ring
+		wantErr   string
+	}{
+		{
+			name:      "open",
+			positions: ring(0, 0, 0, 1, 1, 1),
+			want:      ring(0, 0, 0, 1, 1, 1),
+		},
+		{
+			name:      "closed",
+			positions: ring(0, 0, 0, 1, 1, 1, 0, 0),
+			want:      ring(0, 0, 0, 1, 1, 1),
+		},
+		{
+			name:      "too few positions",
+			positions: ring(0, 0, 0, 1, 0, 0),
+			wantErr:   "ring needs at least 3 positions",
+		},
+		{
+			name:      "invalid position",
+			positions: ring(0, 0, 0, 1, 95, 1),
+			wantErr:   "position 2: invalid latitude 95",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := NewRing(tt.positions)
+			if tt.wantErr != "" {
+				require.Error(t, err)
+				assert.Contains(t, err.Error(), tt.wantErr)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
+
+func TestRing_Contains(t *testing.T) {
+	tests := []struct {
+		name string
+		ring Ring
+		p    polaris.Position
+		want bool
+	}{
+		{"square inside", ring(47, 8, 47, 9, 48, 9, 48, 8), polaris.NewPosition(47.5, 8.5), true},
+		{"square outside east", ring(47, 8, 47, 9, 48, 9, 48, 8), polaris.NewPosition(47.5, 9.5), false},
+		{"square outside north", ring(47, 8, 47, 9, 48, 9, 48, 8), polaris.NewPosition(48.5, 8.5), false},
+		{"square vertex", ring(47, 8, 47, 9, 48, 9, 48, 8), polaris.NewPosition(48, 9), true},
+		{"square meridian edge", ring(47, 8, 47, 9, 48, 9, 48, 8), polaris.NewPosition(47.5, 8), true},
+		{"square on ray through vertex", ring(47, 8, 47, 9, 48, 9, 48, 8), polaris.NewPosition(46, 9), false},
+		{"concave notch", ring(0, 0, 0, 3, 3, 3, 1, 1.5, 3, 0), polaris.NewPosition(2, 1.5), false},
+		{"concave arm", ring(0, 0, 0, 3, 3, 3, 1, 1.5, 3, 0), polaris.NewPosition(2, 0.5), true},
+		{"across antimeridian inside", ring(-10, 170, -10, -170, 10, -170, 10, 170), polaris.NewPosition(0, 180), true},
+		{"across antimeridian inside west", ring(-10, 170, -10, -170, 10, -170, 10, 170), polaris.NewPosition(5, -175), true},
+		{"across antimeridian outside", ring(-10, 170, -10, -170, 10, -170, 10, 170), polaris.NewPosition(0, 0), false},
+		{"across antimeridian beyond edge", ring(-10, 170, -10, -170, 10, -170, 10, 170), polaris.NewPosition(0, -165), false},
+		{"unnormalized longitudes", ring(-10, 170, -10, 190, 10, 190, 10, 170), polaris.NewPosition(0, -179), true},
+		{"around north pole near pole", ring(80, 0, 80, 90, 80, 180, 80, -90), polaris.NewPosition(89.9, -135), true},
+		{"around north pole", ring(80, 0, 80, 90, 80, 180, 80, -90), polaris.NewPosition(85, 45), true},
+		// Great-circle edges bulge towards the pole, up to 82.9°N here.
+		{"around north pole below edge", ring(80, 0, 80, 90, 80, 180, 80, -90), polaris.NewPosition(82, 45), false},
+		{"around north pole at pole", ring(80, 0, 80, 90, 80, 180, 80, -90), polaris.NewPosition(90, 0), true},
+		{"around north pole outside", ring(80, 0, 80, 90, 80, 180, 80, -90), polaris.NewPosition(75, 45), false},
+		{"around north pole south pole", ring(80, 0, 80, 90, 80, 180, 80, -90), polaris.NewPosition(-90, 0), false},
+		{"around south pole", ring(-80, 0, -80, 90, -80, 180, -80, -90), polaris.NewPosition(-85, 100), true},
+		{"around south pole at pole", ring(-80, 0, -80, 90, -80, 180, -80, -90), polaris.NewPosition(-90, 0), true},
+		{"around south pole outside", ring(-80, 0, -80, 90, -80, 180, -80, -90), polaris.NewPosition(-70, 100), false},
+		{"through pole inside", ring(90, 0, 0, 0, 0, 90), polaris.NewPosition(45, 45), true},
+		{"through pole outside", ring(90, 0, 0, 0, 0, 90), polaris.NewPosition(45, 135), false},
+		{"through pole below", ring(90, 0, 0, 0, 0, 90), polaris.NewPosition(-10, 45), false},
+		{"through pole at pole", ring(90, 0, 0, 0, 0, 90), polaris.NewPosition(90, 45), true},
+		{"through pole meridian edge", ring(90, 0, 0, 0, 0, 90), polaris.NewPosition(60, 90), true},
+		{"too few positions", ring(0, 0, 1, 1), polaris.NewPosition(0, 0), false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.want, tt.ring.Contains(tt.p))
+
+			reversed := slices.Clone(tt.ring)
+			slices.Reverse(reversed)
+			assert.Equal(t, tt.want, reversed.Contains(tt.p), "reversed")
+		})
+	}
+}
+
+func TestRing_Area(t *testing.T) {
+	tests := []struct {
+		name  string
+		ring  Ring
+		want  float64
+		delta float64
+	}{
+		{"octant", ring(90, 0, 0, 0, 0, 90), 63758202715511.0, 1},
+		{"octant clockwise", ring(0, 90, 0, 0, 90, 0), 63758202715511.0, 1},
+		{"around north pole", ring(89, 0, 89, 90, 89, 180, 89, 270), 24952305678.0, 1},
+		{"around south pole", ring(-89, 0, -89, 90, -89, 180, -89, 270), 24952305678.0, 1},
+		{"across antimeridian", ring(89, 0.1, 89, 90.1, 89, -179.9), 12476152838.5, 1},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.InDelta(t, tt.want, tt.ring.Area(), tt.delta)
+		})
+	}
+}
+
+func TestRing_Perimeter(t *testing.T) {
+	octant := ring(90, 0, 0, 0, 0, 90)
+
+	// A quarter of the equator and two quarter meridians.
+	assert.InDelta(t, 30022685.63, octant.Perimeter(distance.KarneyDistance), 0.01)
+	// Three quarters of a great circle on the sphere.
+	assert.InDelta(t, 1.5*3.141592653589793*6371000, octant.Perimeter(distance.HaversineDistance), 1e-6)
+	assert.Zero(t, Ring(nil).Perimeter(distance.KarneyDistance))
+}
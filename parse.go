@@ -0,0 +1,384 @@
+package polaris
+
+import (
+	"fmt"
+	"math"
+	"strconv"
+	"strings"
+	"unicode"
+)
+
+// ParsePosition parses a position from a string. The following forms are
+// accepted:
+//
+//   - Decimal degrees separated by a comma or whitespace: "47.3769,8.5417",
+//     "47.3769, 8.5417" or "-33.8568 151.2153".
+//   - Degrees, minutes and seconds with hemisphere letters before or after
+//     the numbers: `47°22'36.8"N 8°32'30.1"E` or `N 47°22'36.8" E 8°32'30.1"`.
+//     Degrees and decimal minutes (`47°22.368'N`) and decimal degrees with
+//     hemisphere letters ("47.3769N, 8.5417E") work as well. Without a
+//     comma, the components may also be separated by whitespace only, as in
+//     "47 22 36.8 N 8 32 30.1 E".
+//   - ISO 6709 strings such as "+47.3769+008.5417/", "+4722.368+00832.502/"
+//     or "+472236.8+0083230.1+408CRSWGS_84/". The height is ignored.
+//   - RFC 5870 geo URIs such as "geo:47.3769,8.5417" or
+//     "geo:47.3769,8.5417,408;u=10". The altitude and parameters are ignored;
+//     a crs other than wgs84 is rejected.
+//
+// Positions in DMS form may list the longitude first if both hemispheres are
+// given. The result must pass [Position.Validate]; otherwise the error wraps
+// [ErrInvalidLatitude] or [ErrInvalidLongitude].
+func ParsePosition(s string) (Position, error) {
+	t := strings.TrimSpace(s)
+	if t == "" {
+		return EmptyPosition, fmt.Errorf("invalid position %q: empty string", s)
+	}
+
+	var (
+		p   Position
+		err error
+	)
+	switch {
+	case len(t) >= 4 && strings.EqualFold(t[:4], "geo:"):
+		p, err = parseGeoURI(t[4:])
+	case isISO6709(t):
+		p, err = parseISO6709(t)
+	default:
+		p, err = parseCoordinatePair(t)
+	}
+	if err != nil {
+		return EmptyPosition, fmt.Errorf("invalid position %q: %w", s, err)
+	}
+
+	if err := p.Validate(); err != nil {
+		return EmptyPosition, fmt.Errorf("invalid position %q: %w", s, err)
+	}
+	return p, nil
+}
+
+// parseGeoURI parses the part of an RFC 5870 geo URI after the scheme.
+func parseGeoURI(s string) (Position, error) {
+	params := strings.Split(s, ";")
+	for _, param := range params[1:] {
+		key, value, _ := strings.Cut(param, "=")
+		if strings.EqualFold(key, "crs") && !strings.EqualFold(value, "wgs84") {
+			return EmptyPosition, fmt.Errorf("unsupported geo URI crs %q", value)
+		}
+	}
+
+	coords := strings.Split(params[0], ",")
+	if len(coords) != 2 && len(coords) != 3 {
+		return EmptyPosition, fmt.Errorf("geo URI must have 2 or 3 coordinates, got %d", len(coords))
+	}
+	lat, err := parseDecimal(coords[0])
+	if err != nil {
+		return EmptyPosition, fmt.Errorf("latitude: %w", err)
+	}
+	lon, err := parseDecimal(coords[1])
+	if err != nil {
+		return EmptyPosition, fmt.Errorf("longitude: %w", err)
+	}
+	if len(coords) == 3 {
+		if _, err := parseDecimal(coords[2]); err != nil {
+			return EmptyPosition, fmt.Errorf("altitude: %w", err)
+		}
+	}
+	return NewPosition(lat, lon), nil
+}
+
+// parseDecimal parses a plain decimal number without exponent, infinities or
+// NaN.
+func parseDecimal(s string) (float64, error) {
+	if s == "" {
+		return 0, fmt.Errorf("missing number")
+	}
+	digits := strings.TrimLeft(s, "+-")
+	if len(s)-len(digits) > 1 || digits == "" {
+		return 0, fmt.Errorf("malformed number %q", s)
+	}
+	dot := false
+	for _, r := range digits {
+		switch {
+		case r == '.' && !dot:
+			dot = true
+		case r < '0' || r > '9':
+			return 0, fmt.Errorf("malformed number %q", s)
+		}
+	}
+	if digits == "." {
+		return 0, fmt.Errorf("malformed number %q", s)
+	}
+	return strconv.ParseFloat(s, 64)
+}
+
+// isISO6709 reports whether s looks like an ISO 6709 string: a signed
+// latitude immediately followed by a signed longitude.
+func isISO6709(s string) bool {
+	if s[0] != '+' && s[0] != '-' {
+		return false
+	}
+	return strings.IndexAny(s[1:], "+-") > 0 && !strings.ContainsAny(s, ", \t")
+}
+
+// parseISO6709 parses an ISO 6709 point such as "+47.3769+008.5417/". The
+// integer part of each coordinate selects between degrees, degrees and
+// minutes, and degrees, minutes and seconds.
+func parseISO6709(s string) (Position, error) {
+	s = strings.TrimSuffix(s, "/")
+	if i := strings.Index(s, "CRS"); i >= 0 {
+		if crs := s[i+3:]; crs != "WGS_84" && crs != "WGS84" {
+			return EmptyPosition, fmt.Errorf("unsupported ISO 6709 CRS %q", crs)
+		}
+		s = s[:i]
+	}
+
+	// Split at the signs into latitude, longitude and an optional height.
+	var parts []string
+	start := 0
+	for i := 1; i <= len(s); i++ {
+		if i == len(s) || s[i] == '+' || s[i] == '-' {
+			parts = append(parts, s[start:i])
+			start = i
+		}
+	}
+	if len(parts) != 2 && len(parts) != 3 {
+		return EmptyPosition, fmt.Errorf("ISO 6709 string must have 2 or 3 components, got %d", len(parts))
+	}
+
+	lat, err := parseISO6709Coordinate(parts[0], 2)
+	if err != nil {
+		return EmptyPosition, fmt.Errorf("latitude: %w", err)
+	}
+	lon, err := parseISO6709Coordinate(parts[1], 3)
+	if err != nil {
+		return EmptyPosition, fmt.Errorf("longitude: %w", err)
+	}
+	if len(parts) == 3 {
+		if _, err := parseDecimal(parts[2]); err != nil {
+			return EmptyPosition, fmt.Errorf("height: %w", err)
+		}
+	}
+	return NewPosition(lat, lon), nil
+}
+
+// parseISO6709Coordinate parses a signed ISO 6709 coordinate whose degrees
+// have the given number of digits.
+func parseISO6709Coordinate(s string, degreeDigits int) (float64, error) {
+	if _, err := parseDecimal(s); err != nil {
+		return 0, err
+	}
+	sign := 1.0
+	if s[0] == '-' {
+		sign = -1
+	}
+	body := s[1:]
+	intPart, fracPart, _ := strings.Cut(body, ".")
+	if fracPart != "" {
+		fracPart = "." + fracPart
+	}
+
+	var deg, min, sec float64
+	switch len(intPart) {
+	case degreeDigits:
+		deg, _ = strconv.ParseFloat(intPart+fracPart, 64)
+	case degreeDigits + 2:
+		deg, _ = strconv.ParseFloat(intPart[:degreeDigits], 64)
+		min, _ = strconv.ParseFloat(intPart[degreeDigits:]+fracPart, 64)
+	case degreeDigits + 4:
+		deg, _ = strconv.ParseFloat(intPart[:degreeDigits], 64)
+		min, _ = strconv.ParseFloat(intPart[degreeDigits:degreeDigits+2], 64)
+		sec, _ = strconv.ParseFloat(intPart[degreeDigits+2:]+fracPart, 64)
+	default:
+		return 0, fmt.Errorf("%q must have %d, %d or %d integer digits", s, degreeDigits, degreeDigits+2, degreeDigits+4)
+	}
+	if min >= 60 || sec >= 60 {
+		return 0, fmt.Errorf("minutes and seconds of %q must be less than 60", s)
+	}
+	return sign * (deg + min/60 + sec/3600), nil
+}
+
+// axis identifies the coordinate given by a hemisphere letter.
+type axis int
+
+const (
+	axisUnknown axis = iota
+	axisLatitude
+	axisLongitude
+)
+
+// parseCoordinatePair parses decimal and DMS forms. It splits the string into
+// latitude and longitude and parses each half with parseCoordinate.
+func parseCoordinatePair(s string) (Position, error) {
+	s = normalizeSymbols(s)
+
+	first, second, err := splitCoordinatePair(s)
+	if err != nil {
+		return EmptyPosition, err
+	}
+
+	v1, a1, err := parseCoordinate(first)
+	if err != nil {
+		return EmptyPosition, err
+	}
+	v2, a2, err := parseCoordinate(second)
+	if err != nil {
+		return EmptyPosition, err
+	}
+
+	switch {
+	case a1 != axisUnknown && a1 == a2:
+		return EmptyPosition, fmt.Errorf("both coordinates are on the same axis")
+	case a1 == axisLongitude || a2 == axisLatitude:
+		return NewPosition(v2, v1), nil
+	default:
+		return NewPosition(v1, v2), nil
+	}
+}
+
+// normalizeSymbols maps typographic variants of the degree, minute and second
+// signs to °, ' and ", and converts the string to upper case.
+func normalizeSymbols(s string) string {
+	return strings.NewReplacer(
+		"º", "°",
+		"′", "'", "’", "'", "‘", "'", "´", "'",
+		"″", `"`, "”", `"`, "“", `"`, "''", `"`,
+	).Replace(strings.ToUpper(s))
+}
+
+// splitCoordinatePair splits a decimal or DMS pair into its two coordinates.
+func splitCoordinatePair(s string) (string, string, error) {
+	if strings.Count(s, ",")+strings.Count(s, ";") == 1 {
+		i := strings.IndexAny(s, ",;")
+		return s[:i], s[i+1:], nil
+	}
+	if strings.ContainsAny(s, ",;") {
+		return "", "", fmt.Errorf("expected a single separator between latitude and longitude")
+	}
+
+	// Hemisphere letters delimit the coordinates: before the numbers in
+	// "N 47 E 8" and after them in "47 N 8 E".
+	if i := strings.IndexAny(s, "NSEW"); i >= 0 {
+		if strings.TrimSpace(s[:i]) == "" {
+			j := strings.IndexAny(s[i+1:], "NSEW")
+			if j < 0 {
+				return "", "", fmt.Errorf("missing second hemisphere letter")
+			}
+			return s[:i+1+j], s[i+1+j:], nil
+		}
+		return s[:i+1], s[i+1:], nil
+	}
+
+	fields := strings.Fields(s)
+	switch {
+	case len(fields) == 2:
+		return fields[0], fields[1], nil
+	case strings.Count(s, "°") == 2:
+		i := strings.Index(s, "°")
+		// The second coordinate starts at the number before the second °.
+		j := strings.Index(s[i+len("°"):], "°") + i + len("°")
+		k := strings.LastIndexFunc(s[:j], func(r rune) bool {
+			return !unicode.IsDigit(r) && r != '.' && r != '+' && r != '-'
+		})
+		return s[:k+1], s[k+1:], nil
+	default:
+		return "", "", fmt.Errorf("cannot separate latitude and longitude; use a comma")
+	}
+}
+
+// parseCoordinate parses a single coordinate in decimal or DMS form with an
+// optional hemisphere letter and returns its value in degrees and the axis
+// given by the hemisphere letter.
+func parseCoordinate(s string) (float64, axis, error) {
+	s = strings.TrimSpace(s)
+	if s == "" {
+		return 0, axisUnknown, fmt.Errorf("missing coordinate")
+	}
+
+	hemisphere := byte(0)
+	switch {
+	case strings.IndexByte("NSEW", s[0]) >= 0:
+		hemisphere, s = s[0], s[1:]
+	case strings.IndexByte("NSEW", s[len(s)-1]) >= 0:
+		hemisphere, s = s[len(s)-1], s[:len(s)-1]
+	}
+	s = strings.TrimSpace(s)
+
+	// Split into numbers, each optionally followed by a unit symbol.
+	type component struct {
+		text string
+		unit byte
+	}
+	var components []component
+	for s != "" {
+		end := strings.IndexFunc(s, func(r rune) bool {
+			return !unicode.IsDigit(r) && r != '.' && r != '+' && r != '-'
+		})
+		if end < 0 {
+			end = len(s)
+		}
+		if end == 0 {
+			return 0, axisUnknown, fmt.Errorf("unexpected character %q", []rune(s)[0])
+		}
+		c := component{text: s[:end]}
+		s = s[end:]
+		switch {
+		case strings.HasPrefix(s, "°"):
+			c.unit, s = 'd', s[len("°"):]
+		case strings.HasPrefix(s, "'"):
+			c.unit, s = 'm', s[1:]
+		case strings.HasPrefix(s, `"`):
+			c.unit, s = 's', s[1:]
+		}
+		components = append(components, c)
+		s = strings.TrimLeftFunc(s, unicode.IsSpace)
+	}
+	if len(components) == 0 {
+		return 0, axisUnknown, fmt.Errorf("missing coordinate")
+	}
+	if len(components) > 3 {
+		return 0, axisUnknown, fmt.Errorf("too many components in coordinate")
+	}
+
+	var values [3]float64
+	negative := false
+	for i, c := range components {
+		want := "dms"[i]
+		if c.unit != 0 && c.unit != want {
+			return 0, axisUnknown, fmt.Errorf("unexpected unit in component %q", c.text)
+		}
+		v, err := parseDecimal(c.text)
+		if err != nil {
+			return 0, axisUnknown, err
+		}
+		if i > 0 && (v < 0 || c.text[0] == '+' || c.text[0] == '-') {
+			return 0, axisUnknown, fmt.Errorf("only degrees may have a sign")
+		}
+		if i < len(components)-1 && strings.Contains(c.text, ".") {
+			return 0, axisUnknown, fmt.Errorf("only the last component may have decimals")
+		}
+		if i > 0 && v >= 60 {
+			return 0, axisUnknown, fmt.Errorf("minutes and seconds must be less than 60")
+		}
+		if i == 0 {
+			negative = c.text[0] == '-'
+			v = math.Abs(v)
+		}
+		values[i] = v
+	}
+
+	value := values[0] + values[1]/60 + values[2]/3600
+	ax := axisUnknown
+	switch hemisphere {
+	case 'N', 'S':
+		ax = axisLatitude
+	case 'E', 'W':
+		ax = axisLongitude
+	}
+	if hemisphere != 0 && (negative || components[0].text[0] == '+') {
+		return 0, axisUnknown, fmt.Errorf("coordinate has both a sign and a hemisphere letter")
+	}
+	if negative || hemisphere == 'S' || hemisphere == 'W' {
+		value = -value
+	}
+	return value, ax, nil
+}
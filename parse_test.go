@@ -0,0 +1,97 @@
+package polaris
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestParsePosition(t *testing.T) {
+	// 47°22'36.84"N 8°32'30.12"E
+	zurich := NewPosition(47+22.0/60+36.84/3600, 8+32.0/60+30.12/3600)
+
+	tests := []struct {
+		name  string
+		input string
+		want  Position
+	}{
+		{name: "decimal with comma", input: "47.3769,8.5417", want: NewPosition(47.3769, 8.5417)},
+		{name: "decimal with comma and space", input: " 47.3769, 8.5417 ", want: NewPosition(47.3769, 8.5417)},
+		{name: "decimal with space", input: "-33.8568 151.2153", want: NewPosition(-33.8568, 151.2153)},
+		{name: "decimal with semicolon", input: "-33.8568;151.2153", want: NewPosition(-33.8568, 151.2153)},
+		{name: "decimal with signs", input: "+47.3769, -8.5417", want: NewPosition(47.3769, -8.5417)},
+		{name: "integers", input: "0,0", want: NewPosition(0, 0)},
+		{name: "limits", input: "-90,180", want: NewPosition(-90, 180)},
+		{name: "String output", input: NewPosition(47.3769, 8.5417).String(), want: NewPosition(47.3769, 8.5417)},
+		{name: "decimal with hemispheres", input: "47.3769N, 8.5417E", want: NewPosition(47.3769, 8.5417)},
+		{name: "decimal with degree signs", input: "33.8568° S 151.2153° E", want: NewPosition(-33.8568, 151.2153)},
+		{name: "dms", input: `47°22'36.84"N 8°32'30.12"E`, want: zurich},
+		{name: "dms with comma", input: `47°22'36.84"N, 8°32'30.12"E`, want: zurich},
+		{name: "dms lower case", input: `47°22'36.84"n 8°32'30.12"e`, want: zurich},
+		{name: "dms with spaces", input: `47° 22' 36.84" N 8° 32' 30.12" E`, want: zurich},
+		{name: "dms prefix hemispheres", input: `N 47°22'36.84" E 8°32'30.12"`, want: zurich},
+		{name: "dms prime symbols", input: "47°22′36.84″N 8°32′30.12″E", want: zurich},
+		{name: "dms without symbols", input: "47 22 36.84 N 8 32 30.12 E", want: zurich},
+		{name: "dms with signs", input: `47°22'36.84" 8°32'30.12"`, want: zurich},
+		{name: "dms west and south", input: `33°51'24.48"S 151°12'55.08"W`, want: NewPosition(-33.8568, -151.2153)},
+		{name: "dms longitude first", input: `8°32'30.12"E 47°22'36.84"N`, want: zurich},
+		{name: "degrees decimal minutes", input: "47°22.614'N 8°32.502'E", want: zurich},
+		{name: "iso 6709 degrees", input: "+47.3769+008.5417/", want: NewPosition(47.3769, 8.5417)},
+		{name: "iso 6709 without solidus", input: "-33.8568+151.2153", want: NewPosition(-33.8568, 151.2153)},
+		{name: "iso 6709 minutes", input: "+4722.614+00832.502/", want: zurich},
+		{name: "iso 6709 seconds", input: "+472236.84+0083230.12/", want: zurich},
+		{name: "iso 6709 height and crs", input: "+47.3769+008.5417+408CRSWGS_84/", want: NewPosition(47.3769, 8.5417)},
+		{name: "geo uri", input: "geo:47.3769,8.5417", want: NewPosition(47.3769, 8.5417)},
+		{name: "geo uri upper case", input: "GEO:47.3769,8.5417", want: NewPosition(47.3769, 8.5417)},
+		{name: "geo uri altitude and parameters", input: "geo:-33.8568,151.2153,12;crs=wgs84;u=35", want: NewPosition(-33.8568, 151.2153)},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := ParsePosition(tt.input)
+			require.NoError(t, err)
+			assert.InDelta(t, tt.want.Latitude, got.Latitude, 1e-9)
+			assert.InDelta(t, tt.want.Longitude, got.Longitude, 1e-9)
+		})
+	}
+}
+
+func TestParsePosition_invalid(t *testing.T) {
+	tests := []struct {
+		name    string
+		input   string
+		wantErr string
+	}{
+		{name: "empty", input: "  ", wantErr: "empty string"},
+		{name: "single number", input: "47.3769", wantErr: "cannot separate"},
+		{name: "three numbers", input: "47.3769,8.5417,3", wantErr: "single separator"},
+		{name: "latitude out of range", input: "95,8", wantErr: "invalid latitude 95"},
+		{name: "longitude out of range", input: "47,190", wantErr: "invalid longitude 190"},
+		{name: "malformed number", input: "47.37.69,8.5417", wantErr: "malformed number"},
+		{name: "NaN", input: "NaN,8", wantErr: "invalid position"},
+		{name: "exponent", input: "4e1,8", wantErr: "invalid position"},
+		{name: "letters", input: "abc,def", wantErr: "unexpected character"},
+		{name: "minutes too large", input: `47°62'N 8°32'E`, wantErr: "less than 60"},
+		{name: "seconds too large", input: `47°22'60"N 8°32'E`, wantErr: "less than 60"},
+		{name: "decimal degrees with minutes", input: `47.5°22'N 8°32'E`, wantErr: "only the last component"},
+		{name: "sign and hemisphere", input: "-47.3769S, 8.5417E", wantErr: "both a sign and a hemisphere"},
+		{name: "same axis", input: "47N 8N", wantErr: "same axis"},
+		{name: "units out of order", input: `47'22°N 8°32'E`, wantErr: "unexpected unit"},
+		{name: "hemisphere only", input: "N,", wantErr: "missing coordinate"},
+		{name: "hemisphere only latitude", input: "N, 8E", wantErr: "missing coordinate"},
+		{name: "hemisphere only with space", input: "S 8E", wantErr: "missing coordinate"},
+		{name: "hemisphere only longitude", input: "47N E", wantErr: "missing coordinate"},
+		{name: "iso 6709 digits", input: "+473.769+008.5417/", wantErr: "integer digits"},
+		{name: "iso 6709 crs", input: "+47.3769+008.5417CRSNAD27/", wantErr: "unsupported ISO 6709 CRS"},
+		{name: "geo uri one coordinate", input: "geo:47.3769", wantErr: "2 or 3 coordinates"},
+		{name: "geo uri crs", input: "geo:47.3769,8.5417;crs=nad27", wantErr: "unsupported geo URI crs"},
+		{name: "geo uri malformed", input: "geo:47.3769,east", wantErr: "longitude: malformed number"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := ParsePosition(tt.input)
+			require.Error(t, err)
+			assert.Contains(t, err.Error(), tt.wantErr)
+		})
+	}
+}
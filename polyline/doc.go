@@ -0,0 +1,12 @@
+// Package polyline encodes paths of positions in the Encoded Polyline
+// Algorithm Format used by Google Maps, OSRM and Valhalla.
+//
+// The format stores the differences between successive positions as
+// variable-length base-64 text, which makes tracks built from successive
+// trilateration estimates compact enough to send to mobile clients. The
+// precision, [Precision5] for Google Maps or [Precision6] for OSRM and
+// Valhalla, must be the same for [Encode] and [Decode]:
+//
+//	s := polyline.Encode(track, polyline.Precision6)
+//	track, err := polyline.Decode(s, polyline.Precision6)
+package polyline
@@ -0,0 +1,33 @@
+package polyline_test
+
+import (
+	"fmt"
+
+	"github.com/ethz-polymaps/polaris"
+	"github.com/ethz-polymaps/polaris/polyline"
+)
+
+func ExampleEncode() {
+	path := []polaris.Position{
+		polaris.NewPosition(38.5, -120.2),
+		polaris.NewPosition(40.7, -120.95),
+		polaris.NewPosition(43.252, -126.453),
+	}
+	fmt.Println(polyline.Encode(path, polyline.Precision5))
+	// Output:
+	// _p~iF~ps|U_ulLnnqC_mqNvxq`@
+}
+
+func ExampleDecode() {
+	path, err := polyline.Decode("_p~iF~ps|U_ulLnnqC_mqNvxq`@", polyline.Precision5)
+	if err != nil {
+		panic(err)
+	}
+	for _, p := range path {
+		fmt.Println(p.Format(polaris.Decimal, 5))
+	}
+	// Output:
+	// 38.50000,-120.20000
+	// 40.70000,-120.95000
+	// 43.25200,-126.45300
+}
@@ -0,0 +1,114 @@
+package polyline
+
+import (
+	"fmt"
+	"math"
+	"strings"
+
+	"github.com/ethz-polymaps/polaris"
+)
+
+// Common precisions, in decimal digits of the coordinates.
+const (
+	// Precision5 is used by Google Maps, about 1 m.
+	Precision5 = 5
+	// Precision6 is used by OSRM and Valhalla, about 10 cm.
+	Precision6 = 6
+)
+
+// MaxPrecision is the highest supported precision. Higher precisions would
+// exceed the accuracy of float64 coordinates.
+const MaxPrecision = 10
+
+// Encode returns the path in the Encoded Polyline Algorithm Format with the
+// given number of decimal digits, usually [Precision5] or [Precision6]. The
+// precision is clamped to [0, MaxPrecision].
+//
+// Each coordinate is rounded before the differences between successive
+// positions are taken, so rounding errors do not accumulate along the path.
+// Longitudes are encoded as given; a path across the antimeridian, for
+// example from 179.9 to -179.9, round-trips unchanged.
+func Encode(path []polaris.Position, precision int) string {
+	factor := math.Pow10(max(0, min(precision, MaxPrecision)))
+
+	var b strings.Builder
+	var prevLat, prevLon int64
+	for _, p := range path {
+		lat := int64(math.Round(p.Latitude * factor))
+		lon := int64(math.Round(p.Longitude * factor))
+		encodeValue(&b, lat-prevLat)
+		encodeValue(&b, lon-prevLon)
+		prevLat, prevLon = lat, lon
+	}
+	return b.String()
+}
+
+// encodeValue writes a signed value as a sequence of 5-bit chunks.
+func encodeValue(b *strings.Builder, v int64) {
+	u := uint64(v) << 1
+	if v < 0 {
+		u = ^u
+	}
+	for u >= 0x20 {
+		b.WriteByte(byte(0x20|u&0x1f) + 63)
+		u >>= 5
+	}
+	b.WriteByte(byte(u) + 63)
+}
+
+// Decode returns the path encoded in s with the given number of decimal
+// digits, which must match the precision used for encoding. The precision is
+// clamped to [0, MaxPrecision].
+func Decode(s string, precision int) ([]polaris.Position, error) {
+	factor := math.Pow10(max(0, min(precision, MaxPrecision)))
+
+	var path []polaris.Position
+	var lat, lon int64
+	for i := 0; i < len(s); {
+		dLat, n, err := decodeValue(s, i)
+		if err != nil {
+			return nil, err
+		}
+		i += n
+		if i == len(s) {
+			return nil, fmt.Errorf("polyline ends after latitude at position %d", i)
+		}
+		dLon, n, err := decodeValue(s, i)
+		if err != nil {
+			return nil, err
+		}
+		i += n
+
+		lat += dLat
+		lon += dLon
+		path = append(path, polaris.NewPosition(float64(lat)/factor, float64(lon)/factor))
+	}
+	return path, nil
+}
+
+// decodeValue reads a signed value starting at s[i] and returns it with the
+// number of bytes read.
+func decodeValue(s string, i int) (int64, int, error) {
+	var u uint64
+	for n, shift := 0, uint(0); ; n, shift = n+1, shift+5 {
+		if i+n >= len(s) {
+			return 0, 0, fmt.Errorf("polyline truncated at position %d", i+n)
+		}
+		c := s[i+n]
+		if c < 63 || c > 126 {
+			return 0, 0, fmt.Errorf("invalid character %q at position %d", c, i+n)
+		}
+		if shift > 60 {
+			return 0, 0, fmt.Errorf("value at position %d too large", i)
+		}
+		chunk := uint64(c - 63)
+		u |= (chunk & 0x1f) << shift
+		if chunk < 0x20 {
+			v := int64(u >> 1)
+			if u&1 != 0 {
+				v = ^v
+			}
+			return v, n + 1, nil
+		}
+	}
+}
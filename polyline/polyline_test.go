@@ -0,0 +1,111 @@
+package polyline
+
+import (
+	"math"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/ethz-polymaps/polaris"
+)
+
+// googlePath is the example of the Encoded Polyline Algorithm Format
+// documentation.
+var googlePath = []polaris.Position{
+	polaris.NewPosition(38.5, -120.2),
+	polaris.NewPosition(40.7, -120.95),
+	polaris.NewPosition(43.252, -126.453),
+}
+
+func TestEncode(t *testing.T) {
+	tests := []struct {
+		name      string
+		path      []polaris.Position
+		precision int
+		want      string
+	}{
+		{name: "google example", path: googlePath, precision: Precision5, want: "_p~iF~ps|U_ulLnnqC_mqNvxq`@"},
+		{name: "single point", path: googlePath[:1], precision: Precision5, want: "_p~iF~ps|U"},
+		{name: "empty", path: nil, precision: Precision5, want: ""},
+		{name: "origin", path: []polaris.Position{polaris.NewPosition(0, 0)}, precision: Precision6, want: "??"},
+		{name: "rounding", path: []polaris.Position{polaris.NewPosition(0.000004, -0.000005)}, precision: Precision5, want: "?@"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.want, Encode(tt.path, tt.precision))
+		})
+	}
+}
+
+func TestDecode(t *testing.T) {
+	got, err := Decode("_p~iF~ps|U_ulLnnqC_mqNvxq`@", Precision5)
+	require.NoError(t, err)
+	require.Len(t, got, len(googlePath))
+	for i := range googlePath {
+		assert.InDelta(t, googlePath[i].Latitude, got[i].Latitude, 1e-9)
+		assert.InDelta(t, googlePath[i].Longitude, got[i].Longitude, 1e-9)
+	}
+
+	got, err = Decode("", Precision5)
+	require.NoError(t, err)
+	assert.Empty(t, got)
+}
+
+func TestDecode_invalid(t *testing.T) {
+	tests := []struct {
+		name    string
+		input   string
+		wantErr string
+	}{
+		{name: "latitude only", input: "_p~iF", wantErr: "ends after latitude"},
+		{name: "truncated value", input: "_p~iF~ps", wantErr: "truncated"},
+		{name: "invalid character", input: "_p~iF ps|U", wantErr: "invalid character"},
+		{name: "too large", input: "______________?", wantErr: "too large"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := Decode(tt.input, Precision5)
+			require.Error(t, err)
+			assert.Contains(t, err.Error(), tt.wantErr)
+		})
+	}
+}
+
+func TestRoundTrip(t *testing.T) {
+	paths := map[string][]polaris.Position{
+		"negative coordinates": {
+			polaris.NewPosition(-33.8568, 151.2153),
+			polaris.NewPosition(-34.6037, -58.3816),
+			polaris.NewPosition(-0.000001, -0.000001),
+			polaris.NewPosition(-90, -180),
+		},
+		"antimeridian": {
+			polaris.NewPosition(-16.5, 179.9999),
+			polaris.NewPosition(-16.6, -179.9999),
+			polaris.NewPosition(-16.7, 180),
+			polaris.NewPosition(-16.8, -180),
+		},
+		"track": {
+			polaris.NewPosition(47.413276, 8.536464),
+			polaris.NewPosition(47.413281, 8.536471),
+			polaris.NewPosition(47.413281, 8.536471),
+			polaris.NewPosition(47.413302, 8.536449),
+		},
+	}
+	for name, path := range paths {
+		for _, precision := range []int{Precision5, Precision6, MaxPrecision} {
+			t.Run(name, func(t *testing.T) {
+				got, err := Decode(Encode(path, precision), precision)
+				require.NoError(t, err)
+				require.Len(t, got, len(path))
+
+				tolerance := 0.5 / math.Pow10(precision)
+				for i := range path {
+					assert.InDelta(t, path[i].Latitude, got[i].Latitude, tolerance+1e-12)
+					assert.InDelta(t, path[i].Longitude, got[i].Longitude, tolerance+1e-12)
+				}
+			})
+		}
+	}
+}
@@ -1,6 +1,11 @@
 package polaris
 
-import "fmt"
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"math"
+)
 
 // Position represents a geographic location using latitude and longitude
 // coordinates in decimal degrees. Latitude ranges from -90 (South) to +90 (North),
@@ -14,13 +19,109 @@ type Position struct {
 // at the intersection of the Prime Meridian and the Equator in the Gulf of Guinea.
 var EmptyPosition Position
 
+// Errors returned by [Position.Validate]. They are wrapped with the offending
+// value and can be tested with [errors.Is].
+var (
+	ErrInvalidLatitude  = errors.New("invalid latitude")
+	ErrInvalidLongitude = errors.New("invalid longitude")
+)
+
 // NewPosition creates a new Position with the given latitude and longitude
 // in decimal degrees.
 func NewPosition(latitude float64, longitude float64) Position {
 	return Position{Latitude: latitude, Longitude: longitude}
 }
 
-// String returns a string representation of the position in "latitude,longitude" format.
+// String returns a string representation of the position in "latitude,longitude"
+// format with six decimal places. Use [Position.Format] for other styles.
 func (l Position) String() string {
 	return fmt.Sprintf("%f,%f", l.Latitude, l.Longitude)
 }
+
+// Validate reports whether the position lies within the valid coordinate
+// ranges: latitude in [-90, 90] and longitude in [-180, 180]. NaN and
+// infinite coordinates are invalid. The returned error wraps
+// [ErrInvalidLatitude] or [ErrInvalidLongitude].
+func (l Position) Validate() error {
+	if !(l.Latitude >= -90 && l.Latitude <= 90) {
+		return fmt.Errorf("%w %v: outside [-90, 90]", ErrInvalidLatitude, l.Latitude)
+	}
+	if !(l.Longitude >= -180 && l.Longitude <= 180) {
+		return fmt.Errorf("%w %v: outside [-180, 180]", ErrInvalidLongitude, l.Longitude)
+	}
+	return nil
+}
+
+// IsValid reports whether [Position.Validate] accepts the position.
+func (l Position) IsValid() bool {
+	return l.Validate() == nil
+}
+
+// Normalize returns the equivalent position with the latitude in [-90, 90]
+// and the longitude in [-180, 180). A latitude beyond a pole is folded back
+// over it, which moves the position to the opposite meridian: latitude 95 at
+// longitude 10 becomes latitude 85 at longitude -170. The longitude then
+// wraps around the antimeridian, so 190 becomes -170 and 180 becomes -180.
+//
+// Positions with NaN or infinite coordinates cannot be normalized and are
+// returned unchanged.
+func (l Position) Normalize() Position {
+	lat, lon := l.Latitude, l.Longitude
+	if math.IsNaN(lat) || math.IsInf(lat, 0) || math.IsNaN(lon) || math.IsInf(lon, 0) {
+		return l
+	}
+
+	// math.Remainder is exact, so values already in range are not altered.
+	lat = math.Remainder(lat, 360)
+	switch {
+	case lat > 90:
+		lat = 180 - lat
+		lon += 180
+	case lat < -90:
+		lat = -180 - lat
+		lon += 180
+	}
+
+	lon = math.Remainder(lon, 360)
+	if lon == 180 {
+		lon = -180
+	}
+	return NewPosition(lat, lon)
+}
+
+// positionJSON is the JSON representation of a Position. The pointers detect
+// missing fields when decoding.
+type positionJSON struct {
+	Latitude  *float64 `json:"latitude"`
+	Longitude *float64 `json:"longitude"`
+}
+
+// MarshalJSON implements [json.Marshaler]. The position is encoded as an
+// object with latitude and longitude in decimal degrees:
+//
+//	{"latitude":47.3769,"longitude":8.5417}
+//
+// Positions with NaN or infinite coordinates cannot be encoded.
+func (l Position) MarshalJSON() ([]byte, error) {
+	return json.Marshal(positionJSON{Latitude: &l.Latitude, Longitude: &l.Longitude})
+}
+
+// UnmarshalJSON implements [json.Unmarshaler]. It accepts the object written
+// by [Position.MarshalJSON], requires both fields and rejects positions that
+// fail [Position.Validate].
+func (l *Position) UnmarshalJSON(data []byte) error {
+	var v positionJSON
+	if err := json.Unmarshal(data, &v); err != nil {
+		return err
+	}
+	if v.Latitude == nil || v.Longitude == nil {
+		return errors.New("position requires latitude and longitude")
+	}
+
+	p := NewPosition(*v.Latitude, *v.Longitude)
+	if err := p.Validate(); err != nil {
+		return err
+	}
+	*l = p
+	return nil
+}
@@ -0,0 +1,93 @@
+package polaris
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"math"
+)
+
+// Position3D is a Position with an altitude in meters above the WGS-84
+// ellipsoid, for example an anchor mounted on a ceiling or a drone in flight.
+// Altitudes from barometers or building plans refer to other datums and have
+// to be converted by the caller.
+type Position3D struct {
+	Position
+	Altitude float64
+}
+
+// ErrInvalidAltitude is returned by [Position3D.Validate] for altitudes that
+// are NaN or infinite.
+var ErrInvalidAltitude = errors.New("invalid altitude")
+
+// NewPosition3D creates a new Position3D with the given latitude and
+// longitude in decimal degrees and altitude in meters.
+func NewPosition3D(latitude, longitude, altitude float64) Position3D {
+	return Position3D{Position: NewPosition(latitude, longitude), Altitude: altitude}
+}
+
+// String returns a string representation of the position in
+// "latitude,longitude,altitude" format with six decimal places.
+func (l Position3D) String() string {
+	return fmt.Sprintf("%f,%f,%f", l.Latitude, l.Longitude, l.Altitude)
+}
+
+// Validate reports whether the horizontal position passes
+// [Position.Validate] and the altitude is finite. The returned error wraps
+// [ErrInvalidLatitude], [ErrInvalidLongitude] or [ErrInvalidAltitude].
+func (l Position3D) Validate() error {
+	if err := l.Position.Validate(); err != nil {
+		return err
+	}
+	if math.IsNaN(l.Altitude) || math.IsInf(l.Altitude, 0) {
+		return fmt.Errorf("%w %v: not finite", ErrInvalidAltitude, l.Altitude)
+	}
+	return nil
+}
+
+// IsValid reports whether [Position3D.Validate] accepts the position.
+func (l Position3D) IsValid() bool {
+	return l.Validate() == nil
+}
+
+// Normalize returns the position with its horizontal part normalized by
+// [Position.Normalize] and the altitude unchanged.
+func (l Position3D) Normalize() Position3D {
+	return Position3D{Position: l.Position.Normalize(), Altitude: l.Altitude}
+}
+
+// position3DJSON is the JSON representation of a Position3D. The pointers
+// detect missing fields when decoding.
+type position3DJSON struct {
+	Latitude  *float64 `json:"latitude"`
+	Longitude *float64 `json:"longitude"`
+	Altitude  *float64 `json:"altitude"`
+}
+
+// MarshalJSON implements [json.Marshaler]. The position is encoded like a
+// [Position] with an additional altitude in meters:
+//
+//	{"latitude":47.3769,"longitude":8.5417,"altitude":408.5}
+func (l Position3D) MarshalJSON() ([]byte, error) {
+	return json.Marshal(position3DJSON{Latitude: &l.Latitude, Longitude: &l.Longitude, Altitude: &l.Altitude})
+}
+
+// UnmarshalJSON implements [json.Unmarshaler]. It accepts the object written
+// by [Position3D.MarshalJSON], requires all three fields and rejects
+// positions that fail [Position3D.Validate].
+func (l *Position3D) UnmarshalJSON(data []byte) error {
+	var v position3DJSON
+	if err := json.Unmarshal(data, &v); err != nil {
+		return err
+	}
+	if v.Latitude == nil || v.Longitude == nil || v.Altitude == nil {
+		return errors.New("position requires latitude, longitude and altitude")
+	}
+
+	p := NewPosition3D(*v.Latitude, *v.Longitude, *v.Altitude)
+	if err := p.Validate(); err != nil {
+		return err
+	}
+	*l = p
+	return nil
+}
@@ -0,0 +1,86 @@
+package polaris
+
+import (
+	"encoding/json"
+	"math"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestPosition3D_Validate(t *testing.T) {
+	tests := []struct {
+		name    string
+		pos     Position3D
+		wantErr error
+	}{
+		{name: "zero", pos: Position3D{}},
+		{name: "ceiling anchor", pos: NewPosition3D(47.4133, 8.5364, 412.5)},
+		{name: "below the ellipsoid", pos: NewPosition3D(31.5, 35.5, -430)},
+		{name: "latitude too large", pos: NewPosition3D(95, 0, 0), wantErr: ErrInvalidLatitude},
+		{name: "longitude NaN", pos: NewPosition3D(0, math.NaN(), 0), wantErr: ErrInvalidLongitude},
+		{name: "altitude NaN", pos: NewPosition3D(0, 0, math.NaN()), wantErr: ErrInvalidAltitude},
+		{name: "altitude infinite", pos: NewPosition3D(0, 0, math.Inf(1)), wantErr: ErrInvalidAltitude},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := tt.pos.Validate()
+			if tt.wantErr == nil {
+				assert.NoError(t, err)
+				assert.True(t, tt.pos.IsValid())
+				return
+			}
+			assert.ErrorIs(t, err, tt.wantErr)
+			assert.False(t, tt.pos.IsValid())
+		})
+	}
+}
+
+func TestPosition3D_String(t *testing.T) {
+	assert.Equal(t, "47.376900,8.541700,408.500000", NewPosition3D(47.3769, 8.5417, 408.5).String())
+}
+
+func TestPosition3D_Normalize(t *testing.T) {
+	got := NewPosition3D(95, 10, 12).Normalize()
+	assert.InDelta(t, 85, got.Latitude, 1e-12)
+	assert.InDelta(t, -170, got.Longitude, 1e-12)
+	assert.Equal(t, 12.0, got.Altitude)
+}
+
+func TestPosition3D_MarshalJSON(t *testing.T) {
+	data, err := json.Marshal(NewPosition3D(47.3769, -8.5417, 408.5))
+	require.NoError(t, err)
+	assert.JSONEq(t, `{"latitude":47.3769,"longitude":-8.5417,"altitude":408.5}`, string(data))
+
+	_, err = json.Marshal(NewPosition3D(0, 0, math.Inf(1)))
+	assert.Error(t, err)
+}
+
+func TestPosition3D_UnmarshalJSON(t *testing.T) {
+	tests := []struct {
+		name    string
+		input   string
+		want    Position3D
+		wantErr bool
+	}{
+		{name: "object", input: `{"latitude":47.3769,"longitude":8.5417,"altitude":408.5}`, want: NewPosition3D(47.3769, 8.5417, 408.5)},
+		{name: "zero altitude", input: `{"latitude":1,"longitude":2,"altitude":0}`, want: NewPosition3D(1, 2, 0)},
+		{name: "missing altitude", input: `{"latitude":47.3769,"longitude":8.5417}`, wantErr: true},
+		{name: "out of range", input: `{"latitude":95,"longitude":8.5417,"altitude":0}`, wantErr: true},
+		{name: "string value", input: `{"latitude":47.3769,"longitude":8.5417,"altitude":"1"}`, wantErr: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var got Position3D
+			err := json.Unmarshal([]byte(tt.input), &got)
+			if tt.wantErr {
+				assert.Error(t, err)
+				assert.Equal(t, Position3D{}, got)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
@@ -0,0 +1,143 @@
+package polaris
+
+import (
+	"encoding/json"
+	"math"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestPosition_Validate(t *testing.T) {
+	tests := []struct {
+		name    string
+		pos     Position
+		wantErr error
+	}{
+		{name: "zero", pos: EmptyPosition},
+		{name: "zurich", pos: NewPosition(47.3769, 8.5417)},
+		{name: "north pole", pos: NewPosition(90, 0)},
+		{name: "south pole", pos: NewPosition(-90, 0)},
+		{name: "antimeridian east", pos: NewPosition(0, 180)},
+		{name: "antimeridian west", pos: NewPosition(0, -180)},
+		{name: "latitude too large", pos: NewPosition(95, 0), wantErr: ErrInvalidLatitude},
+		{name: "latitude too small", pos: NewPosition(-90.0001, 0), wantErr: ErrInvalidLatitude},
+		{name: "latitude NaN", pos: NewPosition(math.NaN(), 0), wantErr: ErrInvalidLatitude},
+		{name: "latitude infinite", pos: NewPosition(math.Inf(1), 0), wantErr: ErrInvalidLatitude},
+		{name: "longitude too large", pos: NewPosition(0, 190), wantErr: ErrInvalidLongitude},
+		{name: "longitude too small", pos: NewPosition(0, -180.5), wantErr: ErrInvalidLongitude},
+		{name: "longitude NaN", pos: NewPosition(0, math.NaN()), wantErr: ErrInvalidLongitude},
+		{name: "longitude infinite", pos: NewPosition(0, math.Inf(-1)), wantErr: ErrInvalidLongitude},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := tt.pos.Validate()
+			if tt.wantErr == nil {
+				assert.NoError(t, err)
+				assert.True(t, tt.pos.IsValid())
+				return
+			}
+			assert.ErrorIs(t, err, tt.wantErr)
+			assert.False(t, tt.pos.IsValid())
+		})
+	}
+}
+
+func TestPosition_ValidateMessage(t *testing.T) {
+	err := NewPosition(95, 0).Validate()
+	assert.EqualError(t, err, "invalid latitude 95: outside [-90, 90]")
+}
+
+func TestPosition_Normalize(t *testing.T) {
+	tests := []struct {
+		name string
+		pos  Position
+		want Position
+	}{
+		{name: "unchanged", pos: NewPosition(47.3769, 8.5417), want: NewPosition(47.3769, 8.5417)},
+		{name: "poles unchanged", pos: NewPosition(90, 10), want: NewPosition(90, 10)},
+		{name: "longitude 190", pos: NewPosition(10, 190), want: NewPosition(10, -170)},
+		{name: "longitude 180", pos: NewPosition(10, 180), want: NewPosition(10, -180)},
+		{name: "longitude -180", pos: NewPosition(10, -180), want: NewPosition(10, -180)},
+		{name: "longitude -190", pos: NewPosition(10, -190), want: NewPosition(10, 170)},
+		{name: "longitude 540", pos: NewPosition(10, 540), want: NewPosition(10, -180)},
+		{name: "longitude 725", pos: NewPosition(10, 725), want: NewPosition(10, 5)},
+		{name: "over the north pole", pos: NewPosition(95, 10), want: NewPosition(85, -170)},
+		{name: "over the south pole", pos: NewPosition(-100, -20), want: NewPosition(-80, 160)},
+		{name: "latitude 180", pos: NewPosition(180, 0), want: NewPosition(0, -180)},
+		{name: "latitude 270", pos: NewPosition(270, 30), want: NewPosition(-90, 30)},
+		{name: "latitude 360", pos: NewPosition(360, 30), want: NewPosition(0, 30)},
+		{name: "latitude -450", pos: NewPosition(-450, 30), want: NewPosition(-90, 30)},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := tt.pos.Normalize()
+			assert.InDelta(t, tt.want.Latitude, got.Latitude, 1e-12)
+			assert.InDelta(t, tt.want.Longitude, got.Longitude, 1e-12)
+			assert.True(t, got.IsValid())
+		})
+	}
+}
+
+func TestPosition_NormalizeInvalid(t *testing.T) {
+	p := NewPosition(math.NaN(), 10)
+	got := p.Normalize()
+	assert.True(t, math.IsNaN(got.Latitude))
+	assert.Equal(t, 10.0, got.Longitude)
+	assert.False(t, got.IsValid())
+}
+
+func TestPosition_MarshalJSON(t *testing.T) {
+	data, err := json.Marshal(NewPosition(47.3769, -8.5417))
+	require.NoError(t, err)
+	assert.JSONEq(t, `{"latitude":47.3769,"longitude":-8.5417}`, string(data))
+
+	// Positions nested in other values use the same representation.
+	data, err = json.Marshal(map[string]Position{"anchor": NewPosition(1, 2)})
+	require.NoError(t, err)
+	assert.JSONEq(t, `{"anchor":{"latitude":1,"longitude":2}}`, string(data))
+
+	_, err = json.Marshal(NewPosition(math.NaN(), 0))
+	assert.Error(t, err)
+}
+
+func TestPosition_UnmarshalJSON(t *testing.T) {
+	tests := []struct {
+		name    string
+		input   string
+		want    Position
+		wantErr bool
+	}{
+		{name: "object", input: `{"latitude":47.3769,"longitude":8.5417}`, want: NewPosition(47.3769, 8.5417)},
+		{name: "field order and case", input: `{"Longitude":8.5417,"Latitude":47.3769}`, want: NewPosition(47.3769, 8.5417)},
+		{name: "zero", input: `{"latitude":0,"longitude":0}`, want: EmptyPosition},
+		{name: "missing longitude", input: `{"latitude":47.3769}`, wantErr: true},
+		{name: "out of range", input: `{"latitude":95,"longitude":8.5417}`, wantErr: true},
+		{name: "not an object", input: `[8.5417,47.3769]`, wantErr: true},
+		{name: "string value", input: `{"latitude":"47.3769","longitude":8.5417}`, wantErr: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var got Position
+			err := json.Unmarshal([]byte(tt.input), &got)
+			if tt.wantErr {
+				assert.Error(t, err)
+				assert.Equal(t, EmptyPosition, got)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
+
+func TestPosition_JSONRoundTrip(t *testing.T) {
+	want := []Position{NewPosition(47.376912345678, 8.541712345678), NewPosition(-90, -180)}
+	data, err := json.Marshal(want)
+	require.NoError(t, err)
+
+	var got []Position
+	require.NoError(t, json.Unmarshal(data, &got))
+	assert.Equal(t, want, got)
+}
@@ -0,0 +1,44 @@
+// Package projection converts positions between WGS-84 geographic coordinates
+// and projected or Cartesian coordinate systems.
+//
+// # Swiss Grids
+//
+// [ToLV95] and [FromLV95] convert between a [polaris.Position] and the Swiss
+// CH1903+/LV95 grid used by swisstopo, [ToLV03] and [FromLV03] do the same for
+// the legacy CH1903/LV03 grid. They implement the rigorous method: a datum
+// shift from WGS-84 to CH1903+ followed by the Swiss oblique conformal
+// cylindrical projection of the Bessel 1841 ellipsoid.
+//
+// The functions with the Approx suffix implement the approximate polynomial
+// formulas published by swisstopo. They are faster but only accurate to about
+// 1 m within Switzerland.
+//
+//	anchor := projection.FromLV95(projection.LV95{East: 2683304, North: 1247926})
+//	m := trilateration.Measurement{Lat: anchor.Latitude, Lon: anchor.Longitude, Distance: 25, Weight: 1}
+//
+// # UTM and MGRS
+//
+// [ToUTM] and [FromUTM] convert between a [polaris.Position] and the Universal
+// Transverse Mercator system on the WGS-84 ellipsoid, using Krüger's series to
+// sixth order. [ToUTM] selects the standard zone including the exceptions for
+// Norway and Svalbard; [ToUTMZone] projects into a given zone instead.
+//
+// [FormatMGRS] and [ParseMGRS] convert to and from Military Grid Reference
+// System strings at every [MGRSPrecision] from 100 km down to 1 m:
+//
+//	ref, err := projection.FormatMGRS(pos, projection.MGRS10m) // "32T MT 6540 4715"
+//	center, precision, err := projection.ParseMGRS("32TMT65404715")
+//
+// # ECEF and Local Frames
+//
+// [ToECEF] and [FromECEF] convert a position and its ellipsoidal height to
+// and from Earth-centered, Earth-fixed Cartesian coordinates on WGS-84.
+//
+// A [LocalFrame] is a metric East-North-Up tangent frame anchored at an
+// origin. Positioning math is simpler in such a frame than in degrees, and
+// conversions in both directions are exact:
+//
+//	frame := projection.NewLocalFrame(origin, 0)
+//	enu := frame.ToENU(anchor, 0)
+//	p, h := frame.FromENU(projection.ENU{East: 10, North: 20})
+package projection
@@ -0,0 +1,101 @@
+package projection
+
+import (
+	"math"
+
+	"github.com/ethz-polymaps/polaris"
+)
+
+// ENU is a coordinate in meters in a local East-North-Up tangent frame.
+type ENU struct {
+	// East is the distance along the local east direction.
+	East float64
+	// North is the distance along the local north direction.
+	North float64
+	// Up is the distance along the ellipsoid normal at the origin.
+	Up float64
+}
+
+// Horizontal returns the distance in meters from the origin projected onto
+// the tangent plane.
+func (c ENU) Horizontal() float64 {
+	return math.Hypot(c.East, c.North)
+}
+
+// Norm returns the straight-line distance in meters from the origin.
+func (c ENU) Norm() float64 {
+	return math.Sqrt(c.East*c.East + c.North*c.North + c.Up*c.Up)
+}
+
+// LocalFrame is a local East-North-Up tangent frame anchored at an origin on
+// the WGS-84 ellipsoid. The East and North axes span the plane tangent to the
+// ellipsoid at the origin and the Up axis follows the ellipsoid normal.
+//
+// Conversions between positions and ENU coordinates are exact rotations and
+// translations of [ECEF] coordinates, so they round-trip without loss at any
+// distance. The tangent plane itself departs from the Earth's surface by
+// about d²/(2R), roughly 8 cm at 1 km and 8 m at 10 km, which shows up in the
+// Up component of positions on the ellipsoid.
+//
+// The zero value is not useful; create frames with [NewLocalFrame].
+type LocalFrame struct {
+	origin       polaris.Position
+	originHeight float64
+	originECEF   ECEF
+
+	sinLat, cosLat float64
+	sinLon, cosLon float64
+}
+
+// NewLocalFrame creates a local East-North-Up frame with its origin at the
+// given position and ellipsoidal height in meters.
+func NewLocalFrame(origin polaris.Position, height float64) LocalFrame {
+	f := LocalFrame{
+		origin:       origin,
+		originHeight: height,
+		originECEF:   ToECEF(origin, height),
+	}
+	f.sinLat, f.cosLat = math.Sincos(origin.Latitude * math.Pi / 180)
+	f.sinLon, f.cosLon = math.Sincos(origin.Longitude * math.Pi / 180)
+	return f
+}
+
+// Origin returns the origin of the frame and its ellipsoidal height.
+func (f LocalFrame) Origin() (polaris.Position, float64) {
+	return f.origin, f.originHeight
+}
+
+// ToENU converts a WGS-84 position and its ellipsoidal height in meters to
+// coordinates in the frame.
+func (f LocalFrame) ToENU(p polaris.Position, height float64) ENU {
+	return f.FromECEF(ToECEF(p, height))
+}
+
+// FromENU converts coordinates in the frame to a WGS-84 position and its
+// ellipsoidal height in meters. It is the inverse of [LocalFrame.ToENU].
+func (f LocalFrame) FromENU(c ENU) (p polaris.Position, height float64) {
+	return FromECEF(f.ToECEF(c))
+}
+
+// FromECEF rotates ECEF coordinates into the frame.
+func (f LocalFrame) FromECEF(c ECEF) ENU {
+	dx := c.X - f.originECEF.X
+	dy := c.Y - f.originECEF.Y
+	dz := c.Z - f.originECEF.Z
+
+	return ENU{
+		East:  -f.sinLon*dx + f.cosLon*dy,
+		North: -f.sinLat*f.cosLon*dx - f.sinLat*f.sinLon*dy + f.cosLat*dz,
+		Up:    f.cosLat*f.cosLon*dx + f.cosLat*f.sinLon*dy + f.sinLat*dz,
+	}
+}
+
+// ToECEF rotates coordinates in the frame back to ECEF coordinates.
+func (f LocalFrame) ToECEF(c ENU) ECEF {
+	// The rotation matrix is orthogonal, so its inverse is its transpose.
+	return ECEF{
+		X: f.originECEF.X - f.sinLon*c.East - f.sinLat*f.cosLon*c.North + f.cosLat*f.cosLon*c.Up,
+		Y: f.originECEF.Y + f.cosLon*c.East - f.sinLat*f.sinLon*c.North + f.cosLat*f.sinLon*c.Up,
+		Z: f.originECEF.Z + f.cosLat*c.North + f.sinLat*c.Up,
+	}
+}
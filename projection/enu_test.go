@@ -0,0 +1,84 @@
+package projection
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/ethz-polymaps/polaris"
+	"github.com/ethz-polymaps/polaris/distance"
+)
+
+func TestLocalFrame_ToENU(t *testing.T) {
+	origin := polaris.NewPosition(47.3769, 8.5417)
+	frame := NewLocalFrame(origin, 0)
+
+	north, _ := distance.VincentyDestination(origin, 0, 1000)
+	east, _ := distance.VincentyDestination(origin, 90, 1000)
+
+	tests := []struct {
+		name   string
+		pos    polaris.Position
+		height float64
+		want   ENU
+		delta  float64
+	}{
+		{name: "origin", pos: origin, height: 0, want: ENU{}, delta: 1e-9},
+		{name: "above origin", pos: origin, height: 100, want: ENU{Up: 100}, delta: 1e-9},
+		// Points on the ellipsoid drop below the tangent plane by about d²/2R.
+		{name: "1 km north", pos: north, height: 0, want: ENU{North: 1000, Up: -0.0785}, delta: 1e-3},
+		{name: "1 km east", pos: east, height: 0, want: ENU{East: 1000, Up: -0.0785}, delta: 1e-3},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := frame.ToENU(tt.pos, tt.height)
+			assert.InDelta(t, tt.want.East, got.East, tt.delta)
+			assert.InDelta(t, tt.want.North, got.North, tt.delta)
+			assert.InDelta(t, tt.want.Up, got.Up, tt.delta)
+		})
+	}
+}
+
+func TestLocalFrameRoundTrip(t *testing.T) {
+	origins := []polaris.Position{
+		polaris.NewPosition(47.3769, 8.5417),
+		polaris.NewPosition(-33.8568, 151.2153),
+		polaris.NewPosition(0, 179.999),
+		polaris.NewPosition(89.9, 45),
+	}
+	for _, origin := range origins {
+		frame := NewLocalFrame(origin, 0)
+		for _, c := range []ENU{
+			{},
+			{East: 123.4, North: -567.8, Up: 9.1},
+			{East: -25000, North: 40000, Up: -150},
+			{East: 1e6, North: 1e6, Up: 0},
+		} {
+			p, h := frame.FromENU(c)
+			got := frame.ToENU(p, h)
+			assert.InDelta(t, c.East, got.East, 1e-6, "%v %v", origin, c)
+			assert.InDelta(t, c.North, got.North, 1e-6, "%v %v", origin, c)
+			assert.InDelta(t, c.Up, got.Up, 1e-6, "%v %v", origin, c)
+		}
+	}
+}
+
+func TestLocalFrame_Origin(t *testing.T) {
+	origin := polaris.NewPosition(46.951083, 7.438632)
+	frame := NewLocalFrame(origin, 550)
+
+	got, h := frame.Origin()
+	assert.Equal(t, origin, got)
+	assert.Equal(t, 550.0, h)
+
+	p, h := frame.FromENU(ENU{})
+	assert.InDelta(t, origin.Latitude, p.Latitude, 1e-12)
+	assert.InDelta(t, origin.Longitude, p.Longitude, 1e-12)
+	assert.InDelta(t, 550, h, 1e-6)
+}
+
+func TestENU_Norm(t *testing.T) {
+	c := ENU{East: 3, North: 4, Up: 12}
+	assert.Equal(t, 5.0, c.Horizontal())
+	assert.Equal(t, 13.0, c.Norm())
+}
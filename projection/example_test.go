@@ -0,0 +1,81 @@
+package projection_test
+
+import (
+	"fmt"
+
+	"github.com/ethz-polymaps/polaris"
+	"github.com/ethz-polymaps/polaris/projection"
+)
+
+func ExampleToLV95() {
+	zurich := polaris.NewPosition(47.3769, 8.5417)
+
+	lv95 := projection.ToLV95(zurich)
+	fmt.Printf("E: %.1f N: %.1f\n", lv95.East, lv95.North)
+
+	lv03 := projection.ToLV03(zurich)
+	fmt.Printf("y: %.1f x: %.1f\n", lv03.Y, lv03.X)
+	// Output:
+	// E: 2683303.9 N: 1247925.6
+	// y: 683303.9 x: 247925.6
+}
+
+func ExampleFromLV95() {
+	// Survey of an anchor in the Swiss grid
+	anchor := projection.FromLV95(projection.LV95{East: 2600000, North: 1200000})
+	fmt.Printf("%.6f, %.6f\n", anchor.Latitude, anchor.Longitude)
+	// Output:
+	// 46.951083, 7.438632
+}
+
+func ExampleToUTM() {
+	eiffel := polaris.NewPosition(48.8582, 2.2945)
+
+	u, err := projection.ToUTM(eiffel)
+	if err != nil {
+		panic(err)
+	}
+	fmt.Println(u)
+	// Output:
+	// 31 N 448251.795 5411932.678
+}
+
+func ExampleFormatMGRS() {
+	eiffel := polaris.NewPosition(48.8582, 2.2945)
+
+	for _, precision := range []projection.MGRSPrecision{projection.MGRS1m, projection.MGRS1km} {
+		ref, err := projection.FormatMGRS(eiffel, precision)
+		if err != nil {
+			panic(err)
+		}
+		fmt.Println(ref)
+	}
+	// Output:
+	// 31U DQ 48251 11932
+	// 31U DQ 48 11
+}
+
+func ExampleParseMGRS() {
+	center, precision, err := projection.ParseMGRS("31UDQ4811")
+	if err != nil {
+		panic(err)
+	}
+	fmt.Printf("%.4f, %.4f (%.0f m square)\n", center.Latitude, center.Longitude, precision.Meters())
+	// Output:
+	// 48.8543, 2.2979 (1000 m square)
+}
+
+func ExampleLocalFrame() {
+	origin := polaris.NewPosition(47.3769, 8.5417)
+	frame := projection.NewLocalFrame(origin, 408)
+
+	anchor := polaris.NewPosition(47.3780, 8.5430)
+	enu := frame.ToENU(anchor, 420)
+	fmt.Printf("E: %.2f N: %.2f U: %.2f\n", enu.East, enu.North, enu.Up)
+
+	p, h := frame.FromENU(enu)
+	fmt.Printf("%.4f, %.4f, %.2f\n", p.Latitude, p.Longitude, h)
+	// Output:
+	// E: 98.18 N: 122.30 U: 12.00
+	// 47.3780, 8.5430, 420.00
+}
@@ -0,0 +1,72 @@
+package projection
+
+import (
+	"math"
+
+	"github.com/ethz-polymaps/polaris"
+	"github.com/ethz-polymaps/polaris/distance"
+)
+
+// ECEF is an Earth-centered, Earth-fixed Cartesian coordinate in meters on the
+// WGS-84 datum. The origin is the center of the Earth, the X axis points to
+// latitude 0° and longitude 0°, the Z axis to the North Pole and the Y axis
+// completes a right-handed system.
+type ECEF struct {
+	X float64
+	Y float64
+	Z float64
+}
+
+// ToECEF converts a WGS-84 position and its ellipsoidal height in meters to
+// ECEF coordinates.
+func ToECEF(p polaris.Position, height float64) ECEF {
+	x, y, z := distance.WGS84().Cartesian(p.Latitude, p.Longitude, height)
+	return ECEF{X: x, Y: y, Z: z}
+}
+
+// FromECEF converts ECEF coordinates to a WGS-84 position and its ellipsoidal
+// height in meters. It is the inverse of [ToECEF] to well below a micrometer.
+func FromECEF(c ECEF) (p polaris.Position, height float64) {
+	lat, lon, h := cartesianToGeodetic(distance.WGS84(), c.X, c.Y, c.Z)
+	return polaris.NewPosition(lat, lon), h
+}
+
+// Distance returns the straight-line distance in meters between two ECEF
+// coordinates. For two points on the surface this is the chord through the
+// Earth, which is shorter than the geodesic distance.
+func (c ECEF) Distance(o ECEF) float64 {
+	return math.Sqrt(sq(c.X-o.X) + sq(c.Y-o.Y) + sq(c.Z-o.Z))
+}
+
+// cartesianToGeodetic converts Earth-centered Cartesian coordinates to
+// geodetic latitude and longitude in degrees and ellipsoidal height in meters
+// on the ellipsoid e.
+func cartesianToGeodetic(e distance.Ellipsoid, x, y, z float64) (lat, lon, h float64) {
+	a := e.SemiMajorAxis
+	e2 := e.EccentricitySquared()
+	p := math.Hypot(x, y)
+
+	// Fixed-point iteration on the latitude. Each step reduces the error by
+	// a factor of about e², so a handful of steps reach machine precision.
+	phi := math.Atan2(z, p*(1-e2))
+	var n float64
+	for i := 0; i < 10; i++ {
+		sinPhi := math.Sin(phi)
+		n = a / math.Sqrt(1-e2*sinPhi*sinPhi)
+		next := math.Atan2(z+e2*n*sinPhi, p)
+		if next == phi {
+			break
+		}
+		phi = next
+	}
+
+	sinPhi, cosPhi := math.Sincos(phi)
+	n = a / math.Sqrt(1-e2*sinPhi*sinPhi)
+	h = p*cosPhi + (z+e2*n*sinPhi)*sinPhi - n
+
+	return phi * 180 / math.Pi, math.Atan2(y, x) * 180 / math.Pi, h
+}
+
+func sq(x float64) float64 {
+	return x * x
+}
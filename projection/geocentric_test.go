@@ -0,0 +1,84 @@
+package projection
+
+import (
+	"math"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/ethz-polymaps/polaris"
+)
+
+func TestToECEF(t *testing.T) {
+	tests := []struct {
+		name   string
+		pos    polaris.Position
+		height float64
+		want   ECEF
+	}{
+		{
+			name: "null island",
+			pos:  polaris.NewPosition(0, 0),
+			want: ECEF{X: 6378137, Y: 0, Z: 0},
+		},
+		{
+			name:   "null island with height",
+			pos:    polaris.NewPosition(0, 0),
+			height: 1000,
+			want:   ECEF{X: 6379137, Y: 0, Z: 0},
+		},
+		{
+			name: "equator at 90 east",
+			pos:  polaris.NewPosition(0, 90),
+			want: ECEF{X: 0, Y: 6378137, Z: 0},
+		},
+		{
+			name: "north pole",
+			pos:  polaris.NewPosition(90, 0),
+			want: ECEF{X: 0, Y: 0, Z: 6356752.314245},
+		},
+		{
+			name: "south pole",
+			pos:  polaris.NewPosition(-90, 0),
+			want: ECEF{X: 0, Y: 0, Z: -6356752.314245},
+		},
+		{
+			name: "45 north 45 east",
+			pos:  polaris.NewPosition(45, 45),
+			want: ECEF{X: 3194419.145, Y: 3194419.145, Z: 4487348.409},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := ToECEF(tt.pos, tt.height)
+			assert.InDelta(t, tt.want.X, got.X, 1e-3)
+			assert.InDelta(t, tt.want.Y, got.Y, 1e-3)
+			assert.InDelta(t, tt.want.Z, got.Z, 1e-3)
+		})
+	}
+}
+
+func TestECEFRoundTrip(t *testing.T) {
+	for _, height := range []float64{-500, 0, 4478, 20200000} {
+		for lat := -90.0; lat <= 90; lat += 7.5 {
+			for lon := -180.0; lon < 180; lon += 15.5 {
+				p := polaris.NewPosition(lat, lon)
+				got, h := FromECEF(ToECEF(p, height))
+				assert.InDelta(t, lat, got.Latitude, 1e-11, "%v %v", p, height)
+				if lat > -90 && lat < 90 {
+					assert.InDelta(t, lon, got.Longitude, 1e-11, "%v %v", p, height)
+				}
+				assert.InDelta(t, height, h, 1e-6, "%v %v", p, height)
+			}
+		}
+	}
+}
+
+func TestECEF_Distance(t *testing.T) {
+	equator := ToECEF(polaris.NewPosition(0, 0), 0)
+	pole := ToECEF(polaris.NewPosition(90, 0), 0)
+	// The chord from the equator to the pole is the hypotenuse of the
+	// semi-axes.
+	assert.InDelta(t, math.Hypot(6378137, 6356752.314245), equator.Distance(pole), 1e-6)
+	assert.Equal(t, 0.0, pole.Distance(pole))
+}
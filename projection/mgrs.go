@@ -0,0 +1,169 @@
+package projection
+
+import (
+	"fmt"
+	"math"
+	"strconv"
+	"strings"
+	"unicode"
+
+	"github.com/ethz-polymaps/polaris"
+)
+
+// MGRSPrecision is the size of the grid square referenced by an MGRS string,
+// expressed through the number of digits of easting and northing.
+type MGRSPrecision int
+
+// MGRS precisions from a 100 km square down to a 1 m square.
+const (
+	MGRS100km MGRSPrecision = iota
+	MGRS10km
+	MGRS1km
+	MGRS100m
+	MGRS10m
+	MGRS1m
+)
+
+// Meters returns the side length of the grid square in meters.
+func (p MGRSPrecision) Meters() float64 {
+	return math.Pow(10, float64(5-p))
+}
+
+// Letters of the MGRS latitude bands and 100 km squares. I and O are never
+// used to avoid confusion with the digits 1 and 0.
+const (
+	mgrsBands = "CDEFGHJKLMNPQRSTUVWXX"
+	mgrsRows  = "ABCDEFGHJKLMNPQRSTUV"
+)
+
+var mgrsColumns = [3]string{"ABCDEFGH", "JKLMNPQR", "STUVWXYZ"}
+
+// FormatMGRS returns the Military Grid Reference System string of a WGS-84
+// position at the given precision, for example "32T MT 65403 47150" at
+// [MGRS1m]. Easting and northing are truncated, not rounded, so the string
+// references the square that contains the position.
+func FormatMGRS(p polaris.Position, precision MGRSPrecision) (string, error) {
+	if precision < MGRS100km || precision > MGRS1m {
+		return "", fmt.Errorf("invalid MGRS precision %d", precision)
+	}
+
+	u, err := ToUTM(p)
+	if err != nil {
+		return "", err
+	}
+
+	band := mgrsBands[int(math.Floor(p.Latitude/8))+10]
+
+	// Guard against rounding errors at the edge of the zone.
+	easting := math.Floor(u.Easting)
+	northing := math.Floor(u.Northing)
+
+	column := mgrsColumns[(u.Zone-1)%3][int(easting/100000)-1]
+	row := mgrsRows[(int(northing/100000)+mgrsRowOffset(u.Zone))%20]
+
+	digits := int(precision)
+	scale := math.Pow(10, float64(5-digits))
+	e := int(math.Mod(easting, 100000) / scale)
+	n := int(math.Mod(northing, 100000) / scale)
+
+	if digits == 0 {
+		return fmt.Sprintf("%d%c %c%c", u.Zone, band, column, row), nil
+	}
+	return fmt.Sprintf("%d%c %c%c %0*d %0*d", u.Zone, band, column, row, digits, e, digits, n), nil
+}
+
+// ParseMGRS parses a Military Grid Reference System string such as
+// "32T MT 65403 47150" or "32TMT6540347150" and returns the center of the
+// referenced grid square together with its precision. Parsing is case
+// insensitive and ignores whitespace.
+func ParseMGRS(s string) (polaris.Position, MGRSPrecision, error) {
+	ref := strings.ToUpper(strings.Map(func(r rune) rune {
+		if unicode.IsSpace(r) {
+			return -1
+		}
+		return r
+	}, s))
+
+	i := 0
+	for i < len(ref) && i < 2 && ref[i] >= '0' && ref[i] <= '9' {
+		i++
+	}
+	if i == 0 {
+		return polaris.EmptyPosition, 0, fmt.Errorf("invalid MGRS %q: missing zone", s)
+	}
+	zone, _ := strconv.Atoi(ref[:i])
+	if zone < 1 || zone > 60 {
+		return polaris.EmptyPosition, 0, fmt.Errorf("invalid MGRS %q: zone %d out of range", s, zone)
+	}
+	if len(ref) < i+3 {
+		return polaris.EmptyPosition, 0, fmt.Errorf("invalid MGRS %q: missing band or square", s)
+	}
+
+	band := strings.IndexByte(mgrsBands[:len(mgrsBands)-1], ref[i])
+	if band < 0 {
+		return polaris.EmptyPosition, 0, fmt.Errorf("invalid MGRS %q: latitude band %q", s, ref[i])
+	}
+	column := strings.IndexByte(mgrsColumns[(zone-1)%3], ref[i+1])
+	if column < 0 {
+		return polaris.EmptyPosition, 0, fmt.Errorf("invalid MGRS %q: column letter %q in zone %d", s, ref[i+1], zone)
+	}
+	row := strings.IndexByte(mgrsRows, ref[i+2])
+	if row < 0 {
+		return polaris.EmptyPosition, 0, fmt.Errorf("invalid MGRS %q: row letter %q", s, ref[i+2])
+	}
+
+	digits := ref[i+3:]
+	if len(digits)%2 != 0 || len(digits) > 10 {
+		return polaris.EmptyPosition, 0, fmt.Errorf("invalid MGRS %q: easting and northing must have the same number of up to 5 digits", s)
+	}
+	for _, r := range digits {
+		if r < '0' || r > '9' {
+			return polaris.EmptyPosition, 0, fmt.Errorf("invalid MGRS %q: non-digit %q in easting or northing", s, r)
+		}
+	}
+	precision := MGRSPrecision(len(digits) / 2)
+	size := precision.Meters()
+
+	var e, n float64
+	if precision > MGRS100km {
+		ev, _ := strconv.Atoi(digits[:precision])
+		nv, _ := strconv.Atoi(digits[precision:])
+		e, n = float64(ev)*size, float64(nv)*size
+	}
+
+	hemisphere := North
+	if band < 10 {
+		hemisphere = South
+	}
+	easting := float64(column+1)*100000 + e + size/2
+	northing := float64((row-mgrsRowOffset(zone)+20)%20)*100000 + n + size/2
+
+	// The row letters repeat every 2,000 km. Pick the repetition that falls
+	// into the latitude band.
+	bandSouth := float64(band*8 - 80)
+	bandNorth := bandSouth + 8
+	if band == len(mgrsBands)-2 {
+		bandNorth = utmMaxLatitude
+	}
+	for k := 0; k < 5; k++ {
+		u := UTM{Zone: zone, Hemisphere: hemisphere, Easting: easting, Northing: northing + float64(k)*2000000}
+		p, err := FromUTM(u)
+		if err != nil {
+			return polaris.EmptyPosition, 0, err
+		}
+		// Allow a margin for squares that straddle the band boundary.
+		if p.Latitude >= bandSouth-0.5 && p.Latitude < bandNorth+0.5 {
+			return p, precision, nil
+		}
+	}
+	return polaris.EmptyPosition, 0, fmt.Errorf("invalid MGRS %q: square %c%c does not lie in band %c", s, ref[i+1], ref[i+2], ref[i])
+}
+
+// mgrsRowOffset returns the offset of the row letters in a zone. Even zones
+// start their rows at F instead of A.
+func mgrsRowOffset(zone int) int {
+	if zone%2 == 0 {
+		return 5
+	}
+	return 0
+}
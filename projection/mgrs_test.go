@@ -0,0 +1,130 @@
+package projection
+
+import (
+	"math"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/ethz-polymaps/polaris"
+)
+
+func TestFormatMGRS(t *testing.T) {
+	eiffel := polaris.NewPosition(48.8582, 2.2945)
+	tests := []struct {
+		name      string
+		pos       polaris.Position
+		precision MGRSPrecision
+		want      string
+	}{
+		{name: "1m", pos: eiffel, precision: MGRS1m, want: "31U DQ 48251 11932"},
+		{name: "10m", pos: eiffel, precision: MGRS10m, want: "31U DQ 4825 1193"},
+		{name: "100m", pos: eiffel, precision: MGRS100m, want: "31U DQ 482 119"},
+		{name: "1km", pos: eiffel, precision: MGRS1km, want: "31U DQ 48 11"},
+		{name: "10km", pos: eiffel, precision: MGRS10km, want: "31U DQ 4 1"},
+		{name: "100km", pos: eiffel, precision: MGRS100km, want: "31U DQ"},
+		{name: "null island", pos: polaris.NewPosition(0, 0), precision: MGRS1m, want: "31N AA 66021 00000"},
+		{name: "even zone rows start at F", pos: polaris.NewPosition(0, 9), precision: MGRS1m, want: "32N NF 00000 00000"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := FormatMGRS(tt.pos, tt.precision)
+			require.NoError(t, err)
+			assert.Equal(t, tt.want, got)
+		})
+	}
+
+	_, err := FormatMGRS(eiffel, MGRS1m+1)
+	assert.Error(t, err)
+	_, err = FormatMGRS(polaris.NewPosition(85, 0), MGRS1m)
+	assert.ErrorIs(t, err, ErrOutsideUTM)
+}
+
+func TestParseMGRS(t *testing.T) {
+	tests := []struct {
+		name      string
+		input     string
+		want      polaris.Position
+		precision MGRSPrecision
+		delta     float64
+	}{
+		{name: "1m", input: "31U DQ 48251 11932", want: polaris.NewPosition(48.8582, 2.2945), precision: MGRS1m, delta: 0.00002},
+		{name: "compact lower case", input: "31udq4825111932", want: polaris.NewPosition(48.8582, 2.2945), precision: MGRS1m, delta: 0.00002},
+		{name: "1km", input: "31U DQ 48 11", want: polaris.NewPosition(48.8582, 2.2945), precision: MGRS1km, delta: 0.01},
+		{name: "100km", input: "31U DQ", want: polaris.NewPosition(48.8582, 2.2945), precision: MGRS100km, delta: 1},
+		{name: "southern hemisphere", input: "56H LH 34900 52288", want: polaris.NewPosition(-33.8568, 151.2153), precision: MGRS1m, delta: 0.00002},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, precision, err := ParseMGRS(tt.input)
+			require.NoError(t, err)
+			assert.Equal(t, tt.precision, precision)
+			assert.InDelta(t, tt.want.Latitude, got.Latitude, tt.delta)
+			assert.InDelta(t, tt.want.Longitude, got.Longitude, tt.delta)
+		})
+	}
+}
+
+func TestParseMGRS_invalid(t *testing.T) {
+	inputs := []string{
+		"",
+		"U DQ 48251 11932",
+		"61U DQ 48251 11932",
+		"31I DQ 48251 11932",
+		"31U IQ 48251 11932",
+		"31U SQ 48251 11932",
+		"31U DO 48251 11932",
+		"31U DQ 48251 1193",
+		"31U DQ 482511 119321",
+		"31U DQ 4825A 11932",
+		"31U",
+	}
+	for _, input := range inputs {
+		t.Run(input, func(t *testing.T) {
+			_, _, err := ParseMGRS(input)
+			assert.Error(t, err)
+		})
+	}
+}
+
+func TestMGRSRoundTrip(t *testing.T) {
+	for lat := -79.5; lat < 84; lat += 6.1 {
+		for lon := -179.5; lon < 180; lon += 13.3 {
+			p := polaris.NewPosition(lat, lon)
+			for precision := MGRS100km; precision <= MGRS1m; precision++ {
+				s, err := FormatMGRS(p, precision)
+				require.NoError(t, err)
+
+				got, parsed, err := ParseMGRS(s)
+				require.NoError(t, err, s)
+				assert.Equal(t, precision, parsed)
+
+				// The center of the square lies within half a diagonal.
+				u, err := ToUTMZone(got, mustUTM(t, p).Zone)
+				require.NoError(t, err)
+				want := mustUTM(t, p)
+				half := precision.Meters() / 2
+				assert.InDelta(t, want.Easting, u.Easting, half+1e-6, s)
+				assert.InDelta(t, want.Northing, u.Northing, half+1e-6, s)
+
+				// Formatting the center yields the same reference, unless the
+				// square is cut by a zone or band boundary and its center lies
+				// on the other side.
+				sameBand := math.Floor(got.Latitude/8) == math.Floor(p.Latitude/8)
+				if mustUTM(t, got).Zone == want.Zone && sameBand {
+					again, err := FormatMGRS(got, precision)
+					require.NoError(t, err)
+					assert.Equal(t, s, again)
+				}
+			}
+		}
+	}
+}
+
+func mustUTM(t *testing.T, p polaris.Position) UTM {
+	t.Helper()
+	u, err := ToUTM(p)
+	require.NoError(t, err)
+	return u
+}
@@ -0,0 +1,242 @@
+package projection
+
+import (
+	"math"
+
+	"github.com/ethz-polymaps/polaris"
+	"github.com/ethz-polymaps/polaris/distance"
+)
+
+// LV95 is a coordinate in the Swiss CH1903+/LV95 grid. The projection center
+// at the old observatory of Bern has the coordinates E = 2,600,000 m and
+// N = 1,200,000 m.
+type LV95 struct {
+	// East is the easting E in meters.
+	East float64
+	// North is the northing N in meters.
+	North float64
+}
+
+// LV03 is a coordinate in the legacy Swiss CH1903/LV03 grid. The projection
+// center at the old observatory of Bern has the coordinates y = 600,000 m and
+// x = 200,000 m.
+type LV03 struct {
+	// Y is the easting y in meters.
+	Y float64
+	// X is the northing x in meters.
+	X float64
+}
+
+// False origins of the Swiss grids.
+const (
+	lv95FalseEasting  = 2600000
+	lv95FalseNorthing = 1200000
+	lv03FalseEasting  = 600000
+	lv03FalseNorthing = 200000
+)
+
+// LV03 returns the coordinate in the LV03 grid by removing the difference of
+// the false origins. The distortions of the LV03 network of up to about
+// 1.6 m are not modelled.
+func (c LV95) LV03() LV03 {
+	return LV03{
+		Y: c.East - (lv95FalseEasting - lv03FalseEasting),
+		X: c.North - (lv95FalseNorthing - lv03FalseNorthing),
+	}
+}
+
+// LV95 returns the coordinate in the LV95 grid by adding the difference of
+// the false origins. The distortions of the LV03 network of up to about
+// 1.6 m are not modelled.
+func (c LV03) LV95() LV95 {
+	return LV95{
+		East:  c.Y + (lv95FalseEasting - lv03FalseEasting),
+		North: c.X + (lv95FalseNorthing - lv03FalseNorthing),
+	}
+}
+
+// ToLV95 converts a WGS-84 position to the LV95 grid using the rigorous
+// method published by swisstopo: a three-parameter datum shift from WGS-84 to
+// CH1903+ followed by the Swiss oblique conformal cylindrical projection of the
+// Bessel 1841 ellipsoid. The position is assumed to lie on the WGS-84
+// ellipsoid; the error caused by a real ellipsoidal height stays below 3 cm per 1000 m.
+//
+// Accuracy: better than 1 mm for the projection itself. The datum shift
+// matches the official transformation to about 1 m.
+func ToLV95(p polaris.Position) LV95 {
+	x, y, z := distance.WGS84().Cartesian(p.Latitude, p.Longitude, 0)
+	lat, lon, _ := cartesianToGeodetic(distance.Bessel1841(), x-ch1903ShiftX, y-ch1903ShiftY, z-ch1903ShiftZ)
+	return swissProject(lat, lon)
+}
+
+// FromLV95 converts an LV95 coordinate to a WGS-84 position using the
+// rigorous method. It is the inverse of [ToLV95].
+func FromLV95(c LV95) polaris.Position {
+	besselLat, besselLon := swissUnproject(c)
+
+	// Choose the Bessel height so that the result lies on the WGS-84
+	// ellipsoid, as assumed by ToLV95. The heights differ by less than 100 m
+	// in Switzerland and the correction converges in a few steps.
+	var lat, lon, besselHeight float64
+	for i := 0; i < 3; i++ {
+		x, y, z := distance.Bessel1841().Cartesian(besselLat, besselLon, besselHeight)
+		var h float64
+		lat, lon, h = cartesianToGeodetic(distance.WGS84(), x+ch1903ShiftX, y+ch1903ShiftY, z+ch1903ShiftZ)
+		besselHeight -= h
+	}
+	return polaris.NewPosition(lat, lon)
+}
+
+// ToLV03 converts a WGS-84 position to the LV03 grid using the rigorous
+// method of [ToLV95].
+func ToLV03(p polaris.Position) LV03 {
+	return ToLV95(p).LV03()
+}
+
+// FromLV03 converts an LV03 coordinate to a WGS-84 position using the
+// rigorous method of [FromLV95].
+func FromLV03(c LV03) polaris.Position {
+	return FromLV95(c.LV95())
+}
+
+// ToLV95Approx converts a WGS-84 position to the LV95 grid using the
+// approximate polynomial formulas published by swisstopo.
+//
+// Accuracy: about 1 m within Switzerland.
+func ToLV95Approx(p polaris.Position) LV95 {
+	// Auxiliary values in units of 10000 arc seconds relative to Bern.
+	phi := (p.Latitude*3600 - 169028.66) / 10000
+	lambda := (p.Longitude*3600 - 26782.5) / 10000
+
+	return LV95{
+		East: 2600072.37 +
+			211455.93*lambda -
+			10938.51*lambda*phi -
+			0.36*lambda*phi*phi -
+			44.54*lambda*lambda*lambda,
+		North: 1200147.07 +
+			308807.95*phi +
+			3745.25*lambda*lambda +
+			76.63*phi*phi -
+			194.56*lambda*lambda*phi +
+			119.79*phi*phi*phi,
+	}
+}
+
+// FromLV95Approx converts an LV95 coordinate to a WGS-84 position using the
+// approximate polynomial formulas published by swisstopo.
+//
+// Accuracy: about 0.1 arc seconds (a few meters) within Switzerland.
+func FromLV95Approx(c LV95) polaris.Position {
+	// Auxiliary values in units of 1000 km relative to Bern.
+	y := (c.East - lv95FalseEasting) / 1000000
+	x := (c.North - lv95FalseNorthing) / 1000000
+
+	lambda := 2.6779094 +
+		4.728982*y +
+		0.791484*y*x +
+		0.1306*y*x*x -
+		0.0436*y*y*y
+	phi := 16.9023892 +
+		3.238272*x -
+		0.270978*y*y -
+		0.002528*x*x -
+		0.0447*y*y*x -
+		0.0140*x*x*x
+
+	// Convert from units of 10000 arc seconds to degrees.
+	return polaris.NewPosition(phi*100/36, lambda*100/36)
+}
+
+// ToLV03Approx converts a WGS-84 position to the LV03 grid using the
+// approximate formulas of [ToLV95Approx].
+func ToLV03Approx(p polaris.Position) LV03 {
+	return ToLV95Approx(p).LV03()
+}
+
+// FromLV03Approx converts an LV03 coordinate to a WGS-84 position using the
+// approximate formulas of [FromLV95Approx].
+func FromLV03Approx(c LV03) polaris.Position {
+	return FromLV95Approx(c.LV95())
+}
+
+// Translation from CH1903+ to WGS-84 in meters.
+const (
+	ch1903ShiftX = 674.374
+	ch1903ShiftY = 15.056
+	ch1903ShiftZ = 405.346
+)
+
+// Parameters of the Swiss oblique conformal cylindrical projection.
+var (
+	// swissLat0 and swissLon0 are the geodetic coordinates of the projection
+	// center in Bern on the Bessel ellipsoid, in radians.
+	swissLat0 = (46 + 57.0/60 + 8.66/3600) * math.Pi / 180
+	swissLon0 = (7 + 26.0/60 + 22.50/3600) * math.Pi / 180
+
+	swissE = math.Sqrt(distance.Bessel1841().EccentricitySquared())
+	// swissR is the radius of the projection sphere.
+	swissR = distance.Bessel1841().LocalRadius(swissLat0 * 180 / math.Pi)
+	// swissAlpha is the ratio of spherical to ellipsoidal longitude.
+	swissAlpha = math.Sqrt(1 + swissE*swissE/(1-swissE*swissE)*math.Pow(math.Cos(swissLat0), 4))
+	// swissB0 is the latitude of the projection center on the sphere.
+	swissB0 = math.Asin(math.Sin(swissLat0) / swissAlpha)
+	// swissK is the integration constant of the latitude mapping.
+	swissK = math.Log(math.Tan(math.Pi/4+swissB0/2)) -
+		swissAlpha*math.Log(math.Tan(math.Pi/4+swissLat0/2)) +
+		swissAlpha*swissE/2*math.Log((1+swissE*math.Sin(swissLat0))/(1-swissE*math.Sin(swissLat0)))
+)
+
+// swissProject maps Bessel latitude and longitude in degrees to LV95.
+func swissProject(lat, lon float64) LV95 {
+	phi := lat * math.Pi / 180
+	lambda := lon * math.Pi / 180
+	sinPhi := math.Sin(phi)
+
+	// Ellipsoid to sphere.
+	s := swissAlpha*math.Log(math.Tan(math.Pi/4+phi/2)) -
+		swissAlpha*swissE/2*math.Log((1+swissE*sinPhi)/(1-swissE*sinPhi)) +
+		swissK
+	b := 2 * (math.Atan(math.Exp(s)) - math.Pi/4)
+	l := swissAlpha * (lambda - swissLon0)
+
+	// Equatorial to pseudo-equatorial system centered in Bern.
+	lBar := math.Atan(math.Sin(l) / (math.Sin(swissB0)*math.Tan(b) + math.Cos(swissB0)*math.Cos(l)))
+	bBar := math.Asin(math.Cos(swissB0)*math.Sin(b) - math.Sin(swissB0)*math.Cos(b)*math.Cos(l))
+
+	// Mercator projection of the sphere.
+	return LV95{
+		East:  swissR*lBar + lv95FalseEasting,
+		North: swissR/2*math.Log((1+math.Sin(bBar))/(1-math.Sin(bBar))) + lv95FalseNorthing,
+	}
+}
+
+// swissUnproject maps LV95 to Bessel latitude and longitude in degrees.
+func swissUnproject(c LV95) (lat, lon float64) {
+	y := c.East - lv95FalseEasting
+	x := c.North - lv95FalseNorthing
+
+	// Inverse Mercator projection.
+	lBar := y / swissR
+	bBar := 2 * (math.Atan(math.Exp(x/swissR)) - math.Pi/4)
+
+	// Pseudo-equatorial to equatorial system.
+	b := math.Asin(math.Cos(swissB0)*math.Sin(bBar) + math.Sin(swissB0)*math.Cos(bBar)*math.Cos(lBar))
+	l := math.Atan(math.Sin(lBar) / (math.Cos(swissB0)*math.Cos(lBar) - math.Sin(swissB0)*math.Tan(bBar)))
+
+	// Sphere to ellipsoid, iterating on the latitude.
+	lambda := swissLon0 + l/swissAlpha
+	phi := b
+	for i := 0; i < 20; i++ {
+		s := (math.Log(math.Tan(math.Pi/4+b/2))-swissK)/swissAlpha +
+			swissE*math.Log(math.Tan(math.Pi/4+math.Asin(swissE*math.Sin(phi))/2))
+		next := 2*math.Atan(math.Exp(s)) - math.Pi/2
+		if math.Abs(next-phi) < 1e-15 {
+			phi = next
+			break
+		}
+		phi = next
+	}
+
+	return phi * 180 / math.Pi, lambda * 180 / math.Pi
+}
@@ -0,0 +1,108 @@
+package projection
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/ethz-polymaps/polaris"
+)
+
+// dms converts degrees, minutes and seconds to decimal degrees.
+func dms(d, m, s float64) float64 {
+	return d + m/60 + s/3600
+}
+
+func TestSwissProjectionConstants(t *testing.T) {
+	// Values from swisstopo, "Formulas and constants for the calculation of
+	// the Swiss conformal cylindrical projection and for the transformation
+	// between coordinate systems".
+	assert.InDelta(t, 6378815.90365, swissR, 0.00001)
+	assert.InDelta(t, 1.00072913843038, swissAlpha, 1e-12)
+	assert.InDelta(t, dms(46, 54, 27.83324844), swissB0*180/3.141592653589793, 1e-10)
+	assert.InDelta(t, 0.0030667323772751, swissK, 1e-12)
+}
+
+func TestToLV95(t *testing.T) {
+	tests := []struct {
+		name   string
+		pos    polaris.Position
+		want   LV95
+		approx LV95
+	}{
+		{
+			// swisstopo reference point for the approximate formulas.
+			name:   "swisstopo example",
+			pos:    polaris.NewPosition(dms(46, 2, 38.87), dms(8, 43, 49.79)),
+			want:   LV95{East: 2700000, North: 1100000},
+			approx: LV95{East: 2699999.76, North: 1099999.97},
+		},
+		{
+			name:   "bern projection center",
+			pos:    polaris.NewPosition(dms(46, 57, 3.898), dms(7, 26, 19.077)),
+			want:   LV95{East: 2600000, North: 1200000},
+			approx: LV95{East: 2599999.98, North: 1200000.02},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := ToLV95(tt.pos)
+			assert.InDelta(t, tt.want.East, got.East, 0.1)
+			assert.InDelta(t, tt.want.North, got.North, 0.1)
+
+			approx := ToLV95Approx(tt.pos)
+			assert.InDelta(t, tt.approx.East, approx.East, 0.01)
+			assert.InDelta(t, tt.approx.North, approx.North, 0.01)
+
+			lv03 := ToLV03(tt.pos)
+			assert.InDelta(t, got.East-2000000, lv03.Y, 1e-6)
+			assert.InDelta(t, got.North-1000000, lv03.X, 1e-6)
+		})
+	}
+}
+
+func TestLV95RoundTrip(t *testing.T) {
+	positions := []polaris.Position{
+		polaris.NewPosition(47.3769, 8.5417),
+		polaris.NewPosition(46.2044, 6.1432),
+		polaris.NewPosition(47.6959, 10.4921),
+		polaris.NewPosition(45.8180, 9.0000),
+		polaris.NewPosition(46.8182, 8.2275),
+	}
+	for _, p := range positions {
+		t.Run(p.String(), func(t *testing.T) {
+			rigorous := FromLV95(ToLV95(p))
+			assert.InDelta(t, p.Latitude, rigorous.Latitude, 1e-10)
+			assert.InDelta(t, p.Longitude, rigorous.Longitude, 1e-10)
+
+			legacy := FromLV03(ToLV03(p))
+			assert.InDelta(t, p.Latitude, legacy.Latitude, 1e-10)
+			assert.InDelta(t, p.Longitude, legacy.Longitude, 1e-10)
+
+			// The approximate formulas agree with the rigorous method to
+			// about a meter in the grid and a few meters, i.e. 4e-5 degrees,
+			// in the inverse direction.
+			approx := FromLV95Approx(ToLV95(p))
+			assert.InDelta(t, p.Latitude, approx.Latitude, 4e-5)
+			assert.InDelta(t, p.Longitude, approx.Longitude, 4e-5)
+
+			grid := ToLV95(p)
+			approxGrid := ToLV95Approx(p)
+			assert.InDelta(t, grid.East, approxGrid.East, 1)
+			assert.InDelta(t, grid.North, approxGrid.North, 1)
+
+			approxLegacy := FromLV03Approx(ToLV03Approx(p))
+			assert.InDelta(t, p.Latitude, approxLegacy.Latitude, 4e-5)
+			assert.InDelta(t, p.Longitude, approxLegacy.Longitude, 4e-5)
+		})
+	}
+}
+
+func TestLV03LV95(t *testing.T) {
+	lv95 := LV95{East: 2683304.03, North: 1247925.60}
+	lv03 := lv95.LV03()
+	assert.InDelta(t, 683304.03, lv03.Y, 1e-9)
+	assert.InDelta(t, 247925.60, lv03.X, 1e-9)
+	assert.InDelta(t, lv95.East, lv03.LV95().East, 1e-9)
+	assert.InDelta(t, lv95.North, lv03.LV95().North, 1e-9)
+}
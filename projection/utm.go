@@ -0,0 +1,258 @@
+package projection
+
+import (
+	"errors"
+	"fmt"
+	"math"
+
+	"github.com/ethz-polymaps/polaris"
+	"github.com/ethz-polymaps/polaris/distance"
+)
+
+// Hemisphere selects the false northing of a UTM coordinate.
+type Hemisphere int
+
+const (
+	// North is the northern hemisphere with a false northing of 0 m.
+	North Hemisphere = iota
+	// South is the southern hemisphere with a false northing of 10,000 km.
+	South
+)
+
+// String returns "N" or "S".
+func (h Hemisphere) String() string {
+	if h == South {
+		return "S"
+	}
+	return "N"
+}
+
+// UTM is a coordinate in the Universal Transverse Mercator system on the
+// WGS-84 ellipsoid.
+type UTM struct {
+	// Zone is the longitude zone from 1 to 60.
+	Zone int
+	// Hemisphere is the hemisphere of the coordinate.
+	Hemisphere Hemisphere
+	// Easting is the easting in meters, including the false easting of 500 km.
+	Easting float64
+	// Northing is the northing in meters, including the false northing of
+	// 10,000 km in the southern hemisphere.
+	Northing float64
+}
+
+// String returns the coordinate in "zone hemisphere easting northing" format,
+// for example "32 N 465403.284 5247150.839".
+func (u UTM) String() string {
+	return fmt.Sprintf("%d %s %.3f %.3f", u.Zone, u.Hemisphere, u.Easting, u.Northing)
+}
+
+// UTM parameters.
+const (
+	utmScale         = 0.9996
+	utmFalseEasting  = 500000
+	utmFalseNorthing = 10000000
+	utmMinLatitude   = -80
+	utmMaxLatitude   = 84
+)
+
+// ErrOutsideUTM is returned for latitudes outside the UTM range from 80°S to
+// 84°N, where the polar stereographic projection applies instead.
+var ErrOutsideUTM = errors.New("latitude outside UTM range [-80, 84]")
+
+// ToUTM converts a WGS-84 position to UTM in its standard zone, taking the
+// exceptions for south-western Norway (zone 32V) and Svalbard (zones 31X,
+// 33X, 35X and 37X) into account. The transverse Mercator projection uses
+// Krüger's series to sixth order in the third flattening, accurate to a few
+// nanometers within a zone.
+func ToUTM(p polaris.Position) (UTM, error) {
+	if p.Latitude < utmMinLatitude || p.Latitude > utmMaxLatitude || math.IsNaN(p.Latitude) {
+		return UTM{}, ErrOutsideUTM
+	}
+	return ToUTMZone(p, utmZone(p.Latitude, p.Longitude))
+}
+
+// ToUTMZone converts a WGS-84 position to UTM in the given zone. Projecting
+// into a neighbouring zone is useful to keep coordinates of a site that
+// straddles a zone boundary in a single system.
+func ToUTMZone(p polaris.Position, zone int) (UTM, error) {
+	if zone < 1 || zone > 60 {
+		return UTM{}, fmt.Errorf("invalid UTM zone %d", zone)
+	}
+	if p.Latitude < utmMinLatitude || p.Latitude > utmMaxLatitude || math.IsNaN(p.Latitude) {
+		return UTM{}, ErrOutsideUTM
+	}
+
+	lon := p.Longitude - utmCentralMeridian(zone)
+	lon = math.Remainder(lon, 360)
+	x, y := utmForward(p.Latitude*math.Pi/180, lon*math.Pi/180)
+
+	u := UTM{
+		Zone:       zone,
+		Hemisphere: North,
+		Easting:    utmFalseEasting + utmScale*x,
+		Northing:   utmScale * y,
+	}
+	if p.Latitude < 0 {
+		u.Hemisphere = South
+		u.Northing += utmFalseNorthing
+	}
+	return u, nil
+}
+
+// FromUTM converts a UTM coordinate to a WGS-84 position.
+func FromUTM(u UTM) (polaris.Position, error) {
+	if u.Zone < 1 || u.Zone > 60 {
+		return polaris.EmptyPosition, fmt.Errorf("invalid UTM zone %d", u.Zone)
+	}
+	if u.Hemisphere != North && u.Hemisphere != South {
+		return polaris.EmptyPosition, fmt.Errorf("invalid hemisphere %d", u.Hemisphere)
+	}
+
+	x := (u.Easting - utmFalseEasting) / utmScale
+	y := u.Northing
+	if u.Hemisphere == South {
+		y -= utmFalseNorthing
+	}
+	y /= utmScale
+
+	lat, lon := utmInverse(x, y)
+	lon = lon*180/math.Pi + utmCentralMeridian(u.Zone)
+	return polaris.NewPosition(lat*180/math.Pi, math.Remainder(lon, 360)), nil
+}
+
+// utmZone returns the standard UTM zone for a position, including the
+// Norway and Svalbard exceptions.
+func utmZone(lat, lon float64) int {
+	lon = math.Remainder(lon, 360)
+	if lon == 180 {
+		lon = -180
+	}
+	zone := int(math.Floor((lon+180)/6)) + 1
+	if zone > 60 {
+		zone = 60
+	}
+
+	switch {
+	case lat >= 56 && lat < 64 && lon >= 3 && lon < 12:
+		zone = 32
+	case lat >= 72 && lon >= 0 && lon < 42:
+		switch {
+		case lon < 9:
+			zone = 31
+		case lon < 21:
+			zone = 33
+		case lon < 33:
+			zone = 35
+		default:
+			zone = 37
+		}
+	}
+	return zone
+}
+
+// utmCentralMeridian returns the central meridian of a zone in degrees.
+func utmCentralMeridian(zone int) float64 {
+	return float64(zone)*6 - 183
+}
+
+// Krüger series coefficients for the WGS-84 ellipsoid.
+var (
+	// tmA is the radius of the rectifying sphere, 2π·tmA being the
+	// circumference of a meridian ellipse.
+	tmA float64
+	// tmAlpha are the coefficients of the forward series.
+	tmAlpha [7]float64
+	// tmBeta are the coefficients of the inverse series.
+	tmBeta [7]float64
+	// tmE is the first eccentricity.
+	tmE float64
+)
+
+func init() {
+	f := distance.WGS84().Flattening
+	n := f / (2 - f)
+	n2 := n * n
+	n3 := n2 * n
+	n4 := n3 * n
+	n5 := n4 * n
+	n6 := n5 * n
+
+	tmE = math.Sqrt(distance.WGS84().EccentricitySquared())
+	tmA = distance.WGS84().SemiMajorAxis / (1 + n) * (1 + n2/4 + n4/64 + n6/256)
+
+	tmAlpha = [7]float64{0,
+		n/2 - 2*n2/3 + 5*n3/16 + 41*n4/180 - 127*n5/288 + 7891*n6/37800,
+		13*n2/48 - 3*n3/5 + 557*n4/1440 + 281*n5/630 - 1983433*n6/1935360,
+		61*n3/240 - 103*n4/140 + 15061*n5/26880 + 167603*n6/181440,
+		49561*n4/161280 - 179*n5/168 + 6601661*n6/7257600,
+		34729*n5/80640 - 3418889*n6/1995840,
+		212378941 * n6 / 319334400,
+	}
+	tmBeta = [7]float64{0,
+		n/2 - 2*n2/3 + 37*n3/96 - n4/360 - 81*n5/512 + 96199*n6/604800,
+		n2/48 + n3/15 - 437*n4/1440 + 46*n5/105 - 1118711*n6/3870720,
+		17*n3/480 - 37*n4/840 - 209*n5/4480 + 5569*n6/90720,
+		4397*n4/161280 - 11*n5/504 - 830251*n6/7257600,
+		4583*n5/161280 - 108847*n6/3991680,
+		20648693 * n6 / 638668800,
+	}
+}
+
+// conformalTan returns the tangent of the conformal latitude for tau, the
+// tangent of the geodetic latitude.
+func conformalTan(tau float64) float64 {
+	sigma := math.Sinh(tmE * math.Atanh(tmE*tau/math.Sqrt(1+tau*tau)))
+	return tau*math.Sqrt(1+sigma*sigma) - sigma*math.Sqrt(1+tau*tau)
+}
+
+// utmForward projects latitude and longitude relative to the central
+// meridian, both in radians, to unscaled transverse Mercator coordinates.
+func utmForward(lat, lon float64) (x, y float64) {
+	tauP := conformalTan(math.Tan(lat))
+	cosLon := math.Cos(lon)
+
+	xiP := math.Atan2(tauP, cosLon)
+	etaP := math.Asinh(math.Sin(lon) / math.Sqrt(tauP*tauP+cosLon*cosLon))
+
+	xi, eta := xiP, etaP
+	for j := 1; j <= 6; j++ {
+		fj := float64(2 * j)
+		xi += tmAlpha[j] * math.Sin(fj*xiP) * math.Cosh(fj*etaP)
+		eta += tmAlpha[j] * math.Cos(fj*xiP) * math.Sinh(fj*etaP)
+	}
+	return tmA * eta, tmA * xi
+}
+
+// utmInverse maps unscaled transverse Mercator coordinates to latitude and
+// longitude relative to the central meridian, both in radians.
+func utmInverse(x, y float64) (lat, lon float64) {
+	xi := y / tmA
+	eta := x / tmA
+
+	xiP, etaP := xi, eta
+	for j := 1; j <= 6; j++ {
+		fj := float64(2 * j)
+		xiP -= tmBeta[j] * math.Sin(fj*xi) * math.Cosh(fj*eta)
+		etaP -= tmBeta[j] * math.Cos(fj*xi) * math.Sinh(fj*eta)
+	}
+
+	sinhEtaP := math.Sinh(etaP)
+	sinXiP, cosXiP := math.Sin(xiP), math.Cos(xiP)
+	tauP := sinXiP / math.Sqrt(sinhEtaP*sinhEtaP+cosXiP*cosXiP)
+
+	// Newton's method for the geodetic latitude from the conformal one.
+	e2 := tmE * tmE
+	tau := tauP
+	for i := 0; i < 10; i++ {
+		tauIP := conformalTan(tau)
+		delta := (tauP - tauIP) / math.Sqrt(1+tauIP*tauIP) *
+			(1 + (1-e2)*tau*tau) / ((1 - e2) * math.Sqrt(1+tau*tau))
+		tau += delta
+		if math.Abs(delta) < 1e-12 {
+			break
+		}
+	}
+
+	return math.Atan(tau), math.Atan2(sinhEtaP, cosXiP)
+}
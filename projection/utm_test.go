@@ -0,0 +1,125 @@
+package projection
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/ethz-polymaps/polaris"
+)
+
+func TestToUTM(t *testing.T) {
+	tests := []struct {
+		name string
+		pos  polaris.Position
+		want UTM
+	}{
+		{
+			name: "null island",
+			pos:  polaris.NewPosition(0, 0),
+			want: UTM{Zone: 31, Hemisphere: North, Easting: 166021.443081, Northing: 0},
+		},
+		{
+			name: "eiffel tower",
+			pos:  polaris.NewPosition(48.8582, 2.2945),
+			want: UTM{Zone: 31, Hemisphere: North, Easting: 448251.795, Northing: 5411932.678},
+		},
+		{
+			name: "central meridian on the equator",
+			pos:  polaris.NewPosition(0, 9),
+			want: UTM{Zone: 32, Hemisphere: North, Easting: 500000, Northing: 0},
+		},
+		{
+			name: "southern hemisphere",
+			pos:  polaris.NewPosition(-33.8568, 151.2153),
+			want: UTM{Zone: 56, Hemisphere: South, Easting: 334900.570, Northing: 6252288.753},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := ToUTM(tt.pos)
+			require.NoError(t, err)
+			assert.Equal(t, tt.want.Zone, got.Zone)
+			assert.Equal(t, tt.want.Hemisphere, got.Hemisphere)
+			assert.InDelta(t, tt.want.Easting, got.Easting, 0.1)
+			assert.InDelta(t, tt.want.Northing, got.Northing, 0.1)
+		})
+	}
+}
+
+func TestToUTM_zoneExceptions(t *testing.T) {
+	tests := []struct {
+		name string
+		pos  polaris.Position
+		want int
+	}{
+		{name: "regular zone", pos: polaris.NewPosition(47.3769, 8.5417), want: 32},
+		{name: "western norway widens 32V", pos: polaris.NewPosition(60.3913, 5.3221), want: 32},
+		{name: "west of 32V", pos: polaris.NewPosition(60, 2.9), want: 31},
+		{name: "south of 32V", pos: polaris.NewPosition(55.9, 5), want: 31},
+		{name: "svalbard 31X", pos: polaris.NewPosition(78, 8), want: 31},
+		{name: "svalbard 33X", pos: polaris.NewPosition(78.2232, 15.6267), want: 33},
+		{name: "svalbard 35X", pos: polaris.NewPosition(79, 25), want: 35},
+		{name: "svalbard 37X", pos: polaris.NewPosition(80, 35), want: 37},
+		{name: "antimeridian", pos: polaris.NewPosition(0, 180), want: 1},
+		{name: "west of antimeridian", pos: polaris.NewPosition(0, 179.9), want: 60},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := ToUTM(tt.pos)
+			require.NoError(t, err)
+			assert.Equal(t, tt.want, got.Zone)
+		})
+	}
+}
+
+func TestToUTM_outsideRange(t *testing.T) {
+	for _, lat := range []float64{-80.5, 84.5, 90} {
+		_, err := ToUTM(polaris.NewPosition(lat, 0))
+		assert.ErrorIs(t, err, ErrOutsideUTM)
+	}
+
+	_, err := ToUTMZone(polaris.NewPosition(0, 0), 61)
+	assert.Error(t, err)
+
+	_, err = FromUTM(UTM{Zone: 0, Easting: 500000})
+	assert.Error(t, err)
+}
+
+func TestUTMRoundTrip(t *testing.T) {
+	for lat := -80.0; lat <= 84; lat += 7.3 {
+		for lon := -180.0; lon < 180; lon += 11.7 {
+			p := polaris.NewPosition(lat, lon)
+			u, err := ToUTM(p)
+			require.NoError(t, err)
+
+			got, err := FromUTM(u)
+			require.NoError(t, err)
+			assert.InDelta(t, p.Latitude, got.Latitude, 1e-11, "%v", p)
+			assert.InDelta(t, p.Longitude, got.Longitude, 1e-11, "%v", p)
+		}
+	}
+}
+
+func TestToUTMZone(t *testing.T) {
+	// A position close to a zone boundary can be projected into both zones.
+	p := polaris.NewPosition(47.5, 11.99)
+
+	own, err := ToUTMZone(p, 32)
+	require.NoError(t, err)
+	neighbour, err := ToUTMZone(p, 33)
+	require.NoError(t, err)
+	assert.Greater(t, own.Easting, 700000.0)
+	assert.Less(t, neighbour.Easting, 300000.0)
+
+	got, err := FromUTM(neighbour)
+	require.NoError(t, err)
+	assert.InDelta(t, p.Latitude, got.Latitude, 1e-11)
+	assert.InDelta(t, p.Longitude, got.Longitude, 1e-11)
+}
+
+func TestUTM_String(t *testing.T) {
+	u := UTM{Zone: 31, Hemisphere: North, Easting: 448251.7951, Northing: 5411932.6782}
+	assert.Equal(t, "31 N 448251.795 5411932.678", u.String())
+}
@@ -0,0 +1,18 @@
+// Package spatial provides an in-memory index over positions for finding the
+// nearest anchors, access points or points of interest.
+//
+// # Index
+//
+// An [Index] is built once from [Item] values that pair a position with an
+// arbitrary value, and answers k-nearest-neighbor, radius and bounding box
+// queries in logarithmic time on average instead of scanning every position:
+//
+//	index, err := spatial.NewIndex(anchors)
+//	nearest := index.KNearest(estimate, 3)
+//	around := index.WithinRadius(estimate, 50)
+//
+// Distances are geodesic distances on the WGS-84 ellipsoid from
+// [distance.KarneyDistance]. The tree partitions Earth-centered Cartesian
+// coordinates, so queries near the poles and across the antimeridian return
+// the same results as a linear scan.
+package spatial
@@ -0,0 +1,45 @@
+package spatial_test
+
+import (
+	"fmt"
+
+	"github.com/ethz-polymaps/polaris"
+	"github.com/ethz-polymaps/polaris/spatial"
+)
+
+func ExampleIndex_KNearest() {
+	index, err := spatial.NewIndex([]spatial.Item[string]{
+		{Position: polaris.NewPosition(47.3769, 8.5417), Value: "Zurich"},
+		{Position: polaris.NewPosition(46.9480, 7.4474), Value: "Bern"},
+		{Position: polaris.NewPosition(46.2044, 6.1432), Value: "Geneva"},
+		{Position: polaris.NewPosition(47.5596, 7.5886), Value: "Basel"},
+	})
+	if err != nil {
+		panic(err)
+	}
+	for _, n := range index.KNearest(polaris.NewPosition(47.05, 8.31), 2) {
+		fmt.Printf("%s: %.1f km\n", n.Value, n.Distance/1000)
+	}
+	// Output:
+	// Zurich: 40.4 km
+	// Bern: 66.6 km
+}
+
+func ExampleIndex_WithinRadius() {
+	index, err := spatial.NewIndex([]spatial.Item[int]{
+		{Position: polaris.NewPosition(47.41331, 8.53644), Value: 1},
+		{Position: polaris.NewPosition(47.41322, 8.53644), Value: 2},
+		{Position: polaris.NewPosition(47.41331, 8.53652), Value: 3},
+		{Position: polaris.NewPosition(47.42000, 8.54000), Value: 4},
+	})
+	if err != nil {
+		panic(err)
+	}
+	for _, n := range index.WithinRadius(polaris.NewPosition(47.41328, 8.53646), 10) {
+		fmt.Printf("anchor %d: %.1f m\n", n.Value, n.Distance)
+	}
+	// Output:
+	// anchor 1: 3.7 m
+	// anchor 3: 5.6 m
+	// anchor 2: 6.8 m
+}
@@ -0,0 +1,259 @@
+package spatial
+
+import (
+	"cmp"
+	"container/heap"
+	"fmt"
+	"math"
+	"slices"
+	"sort"
+
+	"github.com/ethz-polymaps/polaris"
+	"github.com/ethz-polymaps/polaris/distance"
+	"github.com/ethz-polymaps/polaris/projection"
+)
+
+// Item is a position in an [Index] together with the value it carries, such
+// as the ID of an anchor.
+type Item[T any] struct {
+	Position polaris.Position
+	Value    T
+}
+
+// Neighbor is an item found by a distance query.
+type Neighbor[T any] struct {
+	Item[T]
+	// Distance is the geodesic distance in meters from the query position,
+	// computed with [distance.KarneyDistance].
+	Distance float64
+}
+
+// Index is a static k-d tree over positions for nearest-neighbor, radius and
+// bounding box queries. It partitions the Earth-centered Cartesian
+// coordinates of the positions, so it has no trouble with the poles or the
+// antimeridian.
+//
+// An Index is immutable once built and safe for concurrent use by multiple
+// goroutines.
+type Index[T any] struct {
+	// The tree is stored implicitly: the root of the subtree over the range
+	// [lo, hi) is at (lo+hi)/2, with the subtrees over [lo, mid) and
+	// [mid+1, hi) as children.
+	nodes []node[T]
+}
+
+type node[T any] struct {
+	item  Item[T]
+	point [3]float64
+	// axis is the coordinate the node splits its subtree on.
+	axis int
+	// box contains all positions of the subtree rooted at the node.
+	box polaris.BoundingBox
+}
+
+// NewIndex builds an index over the given items. It returns an error if a
+// position fails [polaris.Position.Validate].
+func NewIndex[T any](items []Item[T]) (*Index[T], error) {
+	nodes := make([]node[T], len(items))
+	for i, it := range items {
+		if err := it.Position.Validate(); err != nil {
+			return nil, fmt.Errorf("item %d: %w", i, err)
+		}
+		nodes[i] = node[T]{item: it, point: ecef(it.Position)}
+	}
+	x := &Index[T]{nodes: nodes}
+	x.build(0, len(nodes))
+	return x, nil
+}
+
+// Len returns the number of items in the index.
+func (x *Index[T]) Len() int {
+	return len(x.nodes)
+}
+
+// build arranges the nodes in [lo, hi) as a subtree and sets their boxes.
+func (x *Index[T]) build(lo, hi int) {
+	if lo >= hi {
+		return
+	}
+	sub := x.nodes[lo:hi]
+
+	// Split on the coordinate with the largest spread.
+	axis, spread := 0, -1.0
+	for a := range 3 {
+		minV, maxV := math.Inf(1), math.Inf(-1)
+		for _, n := range sub {
+			minV = math.Min(minV, n.point[a])
+			maxV = math.Max(maxV, n.point[a])
+		}
+		if maxV-minV > spread {
+			axis, spread = a, maxV-minV
+		}
+	}
+	sort.Slice(sub, func(i, j int) bool { return sub[i].point[axis] < sub[j].point[axis] })
+
+	mid := (lo + hi) / 2
+	x.build(lo, mid)
+	x.build(mid+1, hi)
+
+	n := &x.nodes[mid]
+	n.axis = axis
+	n.box = polaris.BoundingBox{SouthWest: n.item.Position, NorthEast: n.item.Position}
+	if lo < mid {
+		n.box = n.box.Union(x.nodes[(lo+mid)/2].box)
+	}
+	if mid+1 < hi {
+		n.box = n.box.Union(x.nodes[(mid+1+hi)/2].box)
+	}
+}
+
+// KNearest returns the k items closest to p, ordered by increasing distance.
+// It returns fewer items if the index holds fewer than k.
+func (x *Index[T]) KNearest(p polaris.Position, k int) []Neighbor[T] {
+	if k <= 0 || len(x.nodes) == 0 {
+		return nil
+	}
+	s := search[T]{index: x, query: p, point: ecef(p), k: k}
+	s.nearest(0, len(x.nodes))
+
+	result := make([]Neighbor[T], len(s.best))
+	for i := len(result) - 1; i >= 0; i-- {
+		result[i] = heap.Pop(&s.best).(Neighbor[T])
+	}
+	return result
+}
+
+// WithinRadius returns the items within the given geodesic distance in
+// meters of p, ordered by increasing distance.
+func (x *Index[T]) WithinRadius(p polaris.Position, meters float64) []Neighbor[T] {
+	if !(meters >= 0) {
+		return nil
+	}
+	var result []Neighbor[T]
+	x.withinRadius(0, len(x.nodes), p, ecef(p), meters, &result)
+	slices.SortFunc(result, func(a, b Neighbor[T]) int {
+		return cmp.Compare(a.Distance, b.Distance)
+	})
+	return result
+}
+
+func (x *Index[T]) withinRadius(lo, hi int, p polaris.Position, point [3]float64, meters float64, result *[]Neighbor[T]) {
+	if lo >= hi {
+		return
+	}
+	mid := (lo + hi) / 2
+	n := &x.nodes[mid]
+
+	// The chord through the Earth is never longer than the geodesic, so it
+	// rules out most items without the exact distance.
+	if chord(point, n.point) <= meters {
+		if d := distance.KarneyDistance(p, n.item.Position); d <= meters {
+			*result = append(*result, Neighbor[T]{Item: n.item, Distance: d})
+		}
+	}
+
+	diff := point[n.axis] - n.point[n.axis]
+	if diff <= meters {
+		x.withinRadius(lo, mid, p, point, meters, result)
+	}
+	if -diff <= meters {
+		x.withinRadius(mid+1, hi, p, point, meters, result)
+	}
+}
+
+// InBoundingBox returns the items inside the box or on its edges, in no
+// particular order. Boxes across the antimeridian are supported.
+func (x *Index[T]) InBoundingBox(b polaris.BoundingBox) []Item[T] {
+	var result []Item[T]
+	x.inBoundingBox(0, len(x.nodes), b, &result)
+	return result
+}
+
+func (x *Index[T]) inBoundingBox(lo, hi int, b polaris.BoundingBox, result *[]Item[T]) {
+	if lo >= hi {
+		return
+	}
+	mid := (lo + hi) / 2
+	n := &x.nodes[mid]
+	if !n.box.Intersects(b) {
+		return
+	}
+	if b.Contains(n.item.Position) {
+		*result = append(*result, n.item)
+	}
+	x.inBoundingBox(lo, mid, b, result)
+	x.inBoundingBox(mid+1, hi, b, result)
+}
+
+// search holds the state of a k-nearest-neighbor query.
+type search[T any] struct {
+	index *Index[T]
+	query polaris.Position
+	point [3]float64
+	k     int
+	best  neighborHeap[T]
+}
+
+// worst returns the distance that a candidate has to beat, +Inf while fewer
+// than k items are found.
+func (s *search[T]) worst() float64 {
+	if len(s.best) < s.k {
+		return math.Inf(1)
+	}
+	return s.best[0].Distance
+}
+
+func (s *search[T]) nearest(lo, hi int) {
+	if lo >= hi {
+		return
+	}
+	mid := (lo + hi) / 2
+	n := &s.index.nodes[mid]
+
+	if chord(s.point, n.point) < s.worst() {
+		if d := distance.KarneyDistance(s.query, n.item.Position); d < s.worst() {
+			if len(s.best) == s.k {
+				heap.Pop(&s.best)
+			}
+			heap.Push(&s.best, Neighbor[T]{Item: n.item, Distance: d})
+		}
+	}
+
+	// Visit the side of the query first, and the other side only if the
+	// splitting plane is closer than the worst distance found.
+	diff := s.point[n.axis] - n.point[n.axis]
+	near, far := [2]int{lo, mid}, [2]int{mid + 1, hi}
+	if diff > 0 {
+		near, far = far, near
+	}
+	s.nearest(near[0], near[1])
+	if math.Abs(diff) < s.worst() {
+		s.nearest(far[0], far[1])
+	}
+}
+
+// neighborHeap is a max-heap of neighbors by distance.
+type neighborHeap[T any] []Neighbor[T]
+
+func (h neighborHeap[T]) Len() int           { return len(h) }
+func (h neighborHeap[T]) Less(i, j int) bool { return h[i].Distance > h[j].Distance }
+func (h neighborHeap[T]) Swap(i, j int)      { h[i], h[j] = h[j], h[i] }
+func (h *neighborHeap[T]) Push(x any)        { *h = append(*h, x.(Neighbor[T])) }
+func (h *neighborHeap[T]) Pop() any {
+	old := *h
+	n := old[len(old)-1]
+	*h = old[:len(old)-1]
+	return n
+}
+
+// ecef returns the Earth-centered Cartesian coordinates of a position on the
+// surface of the WGS-84 ellipsoid.
+func ecef(p polaris.Position) [3]float64 {
+	c := projection.ToECEF(p, 0)
+	return [3]float64{c.X, c.Y, c.Z}
+}
+
+// chord returns the straight-line distance between two Cartesian points.
+func chord(a, b [3]float64) float64 {
+	return math.Sqrt((a[0]-b[0])*(a[0]-b[0]) + (a[1]-b[1])*(a[1]-b[1]) + (a[2]-b[2])*(a[2]-b[2]))
+}
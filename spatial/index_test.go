@@ -0,0 +1,188 @@
+package spatial
+
+import (
+	"cmp"
+	"math/rand/v2"
+	"slices"
+	"sync"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/ethz-polymaps/polaris"
+	"github.com/ethz-polymaps/polaris/distance"
+)
+
+// randomItems returns n items spread over the globe, with clusters around
+// the poles and the antimeridian, carrying their index as value.
+func randomItems(n int) []Item[int] {
+	r := rand.New(rand.NewPCG(1, 2))
+	items := make([]Item[int], n)
+	for i := range items {
+		var lat, lon float64
+		switch i % 4 {
+		case 0:
+			lat, lon = r.Float64()*180-90, r.Float64()*360-180
+		case 1:
+			lat, lon = 85+r.Float64()*5, r.Float64()*360-180
+		case 2:
+			lat, lon = r.Float64()*2-1, 179+r.Float64()*2
+		default:
+			lat, lon = 47+r.Float64()*0.01, 8.5+r.Float64()*0.01
+		}
+		items[i] = Item[int]{Position: polaris.NewPosition(lat, lon).Normalize(), Value: i}
+	}
+	return items
+}
+
+// bruteForce returns all items with their distances to p, ordered by
+// increasing distance.
+func bruteForce(items []Item[int], p polaris.Position) []Neighbor[int] {
+	result := make([]Neighbor[int], len(items))
+	for i, it := range items {
+		result[i] = Neighbor[int]{Item: it, Distance: distance.KarneyDistance(p, it.Position)}
+	}
+	slices.SortFunc(result, func(a, b Neighbor[int]) int {
+		return cmp.Compare(a.Distance, b.Distance)
+	})
+	return result
+}
+
+var queries = []polaris.Position{
+	polaris.NewPosition(47.005, 8.505),
+	polaris.NewPosition(90, 0),
+	polaris.NewPosition(87, -100),
+	polaris.NewPosition(0, 180),
+	polaris.NewPosition(0.5, -179.9),
+	polaris.NewPosition(-33.8568, 151.2153),
+	polaris.NewPosition(-90, 0),
+}
+
+func TestNewIndex(t *testing.T) {
+	x, err := NewIndex(randomItems(100))
+	require.NoError(t, err)
+	assert.Equal(t, 100, x.Len())
+
+	empty, err := NewIndex[string](nil)
+	require.NoError(t, err)
+	assert.Empty(t, empty.KNearest(queries[0], 3))
+	assert.Empty(t, empty.WithinRadius(queries[0], 1000))
+	assert.Empty(t, empty.InBoundingBox(polaris.BoundingBox{
+		SouthWest: polaris.NewPosition(-90, -180),
+		NorthEast: polaris.NewPosition(90, 180),
+	}))
+
+	_, err = NewIndex([]Item[string]{
+		{Position: polaris.NewPosition(47, 8), Value: "a"},
+		{Position: polaris.NewPosition(47, 188), Value: "b"},
+	})
+	assert.ErrorIs(t, err, polaris.ErrInvalidLongitude)
+	assert.ErrorContains(t, err, "item 1:")
+}
+
+func TestIndex_KNearest(t *testing.T) {
+	items := randomItems(500)
+	x, err := NewIndex(items)
+	require.NoError(t, err)
+
+	for _, q := range queries {
+		want := bruteForce(items, q)
+		for _, k := range []int{1, 5, 40} {
+			got := x.KNearest(q, k)
+			require.Len(t, got, k)
+			for i := range got {
+				assert.InDelta(t, want[i].Distance, got[i].Distance, 1e-9, "%v k=%d i=%d", q, k, i)
+			}
+		}
+	}
+
+	assert.Len(t, x.KNearest(queries[0], 1000), 500)
+	assert.Nil(t, x.KNearest(queries[0], 0))
+}
+
+func TestIndex_WithinRadius(t *testing.T) {
+	items := randomItems(500)
+	x, err := NewIndex(items)
+	require.NoError(t, err)
+
+	for _, q := range queries {
+		for _, meters := range []float64{0, 500, 200000, 2000000} {
+			var want []Neighbor[int]
+			for _, n := range bruteForce(items, q) {
+				if n.Distance <= meters {
+					want = append(want, n)
+				}
+			}
+			got := x.WithinRadius(q, meters)
+			require.Len(t, got, len(want), "%v within %v m", q, meters)
+			for i := range got {
+				assert.InDelta(t, want[i].Distance, got[i].Distance, 1e-9)
+			}
+		}
+	}
+
+	assert.Nil(t, x.WithinRadius(queries[0], -1))
+}
+
+func TestIndex_InBoundingBox(t *testing.T) {
+	items := randomItems(500)
+	x, err := NewIndex(items)
+	require.NoError(t, err)
+
+	boxes := []polaris.BoundingBox{
+		{SouthWest: polaris.NewPosition(47.002, 8.502), NorthEast: polaris.NewPosition(47.008, 8.507)},
+		{SouthWest: polaris.NewPosition(-0.5, 179.5), NorthEast: polaris.NewPosition(0.5, -179.5)},
+		{SouthWest: polaris.NewPosition(88, -180), NorthEast: polaris.NewPosition(90, 180)},
+		{SouthWest: polaris.NewPosition(-30, 10), NorthEast: polaris.NewPosition(30, 100)},
+	}
+	for _, b := range boxes {
+		var want []int
+		for _, it := range items {
+			if b.Contains(it.Position) {
+				want = append(want, it.Value)
+			}
+		}
+		var got []int
+		for _, it := range x.InBoundingBox(b) {
+			got = append(got, it.Value)
+		}
+		assert.NotEmpty(t, want, "%v", b)
+		assert.ElementsMatch(t, want, got, "%v", b)
+	}
+}
+
+func TestIndex_concurrentReads(t *testing.T) {
+	items := randomItems(200)
+	x, err := NewIndex(items)
+	require.NoError(t, err)
+	want := x.KNearest(queries[0], 10)
+
+	var wg sync.WaitGroup
+	for range 8 {
+		wg.Go(func() {
+			for range 20 {
+				assert.Equal(t, want, x.KNearest(queries[0], 10))
+				x.WithinRadius(queries[3], 100000)
+			}
+		})
+	}
+	wg.Wait()
+}
+
+func BenchmarkIndex_KNearest(b *testing.B) {
+	items := randomItems(10000)
+	x, err := NewIndex(items)
+	require.NoError(b, err)
+
+	b.Run("index", func(b *testing.B) {
+		for b.Loop() {
+			x.KNearest(queries[0], 5)
+		}
+	})
+	b.Run("linear scan", func(b *testing.B) {
+		for b.Loop() {
+			bruteForce(items, queries[0])
+		}
+	})
+}
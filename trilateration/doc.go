@@ -26,6 +26,10 @@
 //	}
 //	position, accuracy, err := t.Trilaterate(measurements)
 //
+// Measurement coordinates are checked with [polaris.Position.Validate]; an
+// invalid latitude or longitude is reported as an error instead of silently
+// distorting the estimate.
+//
 // # Weights
 //
 // Each measurement includes a Weight field that indicates the confidence level.
@@ -34,6 +38,45 @@
 //   - Less reliable signal sources
 //   - Older measurements in time-series data
 //
+// # Diagnostics
+//
+// [Trilaterator.Solve] returns a [Result] with the residual of every
+// measurement in meters, the final cost, the initial guess, the number of
+// iterations and function evaluations and the termination status of the
+// optimizer. Large residuals point at the measurements that disagree with
+// the estimate:
+//
+//	result, err := t.Solve(measurements)
+//	for i, r := range result.Residuals {
+//	    // ...
+//	}
+//
+// # Three Dimensions
+//
+// [Trilaterator.Trilaterate3D] estimates the altitude together with the
+// position, for drones or tags on different floors. Each measurement carries
+// the altitude of its anchor in Alt, and the result is a [polaris.Position3D]
+// with separate horizontal and vertical accuracies:
+//
+//	position, horizontal, vertical, err := t.Trilaterate3D(measurements)
+//
+// Anchors that all lie in one plane, such as on a ceiling, determine the
+// altitude only up to a reflection across that plane and poorly near it;
+// Trilaterate3D prefers the position below the anchors and reports the weak
+// geometry through the vertical accuracy. Anchors at different heights give
+// the best vertical fixes.
+//
+// # Known Target Height
+//
+// If every target sits at a known height, such as a forklift antenna, a
+// three-dimensional solve is unnecessary. [WithTargetHeight] makes
+// [Trilaterator.Trilaterate] project the slant ranges from the anchors at
+// their altitude Alt onto the horizontal plane and solve in two dimensions.
+// Ranges shorter than the height difference are reported with
+// [ErrRangeTooShort]:
+//
+//	t := trilateration.NewTrilaterator(trilateration.WithTargetHeight(409.5))
+//
 // # Configuration
 //
 // The default [Trilaterator] uses [distance.HaversineDistance] for distance calculations.
@@ -42,4 +85,8 @@
 //	t := trilateration.NewTrilaterator(
 //	    trilateration.WithDistanceFunc(distance.VincentyDistance),
 //	)
+//
+// A Trilaterator accepts up to three measurements per call by default; use
+// [WithMaxMeasurements] to raise the limit. [Trilaterator.Trilaterate3D]
+// accepts 3 to 8 measurements by default, set with [WithMaxMeasurements3D].
 package trilateration
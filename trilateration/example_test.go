@@ -77,3 +77,80 @@ func ExampleTrilaterator_Trilaterate_withWeights() {
 	// Position: 47.4124, 8.5418
 	// Accuracy: 45.52 meters
 }
+
+func ExampleTrilaterator_Trilaterate3D() {
+	t := trilateration.NewTrilaterator()
+
+	// Three anchors on the ground and one on a mast measure slant ranges to
+	// a drone. Alt is the anchor altitude in meters above the ellipsoid.
+	measurements := []trilateration.Measurement{
+		{Lat: 47.41463, Lon: 8.53646, Alt: 430, Distance: 146.10, Weight: 1.0},
+		{Lat: 47.41261, Lon: 8.53817, Alt: 432, Distance: 155.11, Weight: 1.0},
+		{Lat: 47.41261, Lon: 8.53475, Alt: 429, Distance: 172.60, Weight: 1.0},
+		{Lat: 47.41328, Lon: 8.53646, Alt: 470, Distance: 19.74, Weight: 1.0},
+	}
+
+	position, horizontal, vertical, err := t.Trilaterate3D(measurements)
+	if err != nil {
+		panic(err)
+	}
+
+	fmt.Printf("Position: %.5f, %.5f at %.1f m\n", position.Latitude, position.Longitude, position.Altitude)
+	fmt.Printf("Accuracy: %.2f m horizontal, %.2f m vertical\n", horizontal, vertical)
+	// Output:
+	// Position: 47.41340, 8.53660 at 480.1 m
+	// Accuracy: 0.02 m horizontal, 0.03 m vertical
+}
+
+func ExampleWithTargetHeight() {
+	// The antenna of a forklift is 1.5 m above a floor at 408 m, and the
+	// anchors hang from the ceiling at 413.5 m.
+	t := trilateration.NewTrilaterator(
+		trilateration.WithDistanceFunc(distance.VincentyDistance),
+		trilateration.WithTargetHeight(409.5),
+	)
+
+	measurements := []trilateration.Measurement{
+		{Lat: 47.41333, Lon: 8.53646, Alt: 413.5, Distance: 7.21, Weight: 1.0},
+		{Lat: 47.41325, Lon: 8.53652, Alt: 413.5, Distance: 7.21, Weight: 1.0},
+		{Lat: 47.41325, Lon: 8.53640, Alt: 413.5, Distance: 7.21, Weight: 1.0},
+	}
+
+	position, accuracy, err := t.Trilaterate(measurements)
+	if err != nil {
+		panic(err)
+	}
+
+	fmt.Printf("Position: %.5f, %.5f\n", position.Latitude, position.Longitude)
+	fmt.Printf("Accuracy: %.2f meters\n", accuracy)
+	// Output:
+	// Position: 47.41328, 8.53646
+	// Accuracy: 0.23 meters
+}
+
+func ExampleTrilaterator_Solve() {
+	t := trilateration.NewTrilaterator()
+
+	measurements := []trilateration.Measurement{
+		{Lat: 47.4133, Lon: 8.5364, Distance: 500, Weight: 1.0},
+		{Lat: 47.4100, Lon: 8.5400, Distance: 300, Weight: 1.0},
+		{Lat: 47.4120, Lon: 8.5450, Distance: 400, Weight: 1.0},
+	}
+
+	result, err := t.Solve(measurements)
+	if err != nil {
+		panic(err)
+	}
+
+	fmt.Printf("Position: %.4f, %.4f\n", result.Position.Latitude, result.Position.Longitude)
+	for i, r := range result.Residuals {
+		fmt.Printf("Residual %d: %.1f m\n", i, r)
+	}
+	fmt.Println("Status:", result.Status)
+	// Output:
+	// Position: 47.4094, 8.5423
+	// Residual 0: 115.2 m
+	// Residual 1: -118.7 m
+	// Residual 2: -49.3 m
+	// Status: FunctionConvergence
+}
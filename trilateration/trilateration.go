@@ -22,6 +22,11 @@ type Measurement struct {
 	Lat float64
 	// Lon is the longitude of the reference point in decimal degrees.
 	Lon float64
+	// Alt is the altitude of the reference point in meters above the WGS-84
+	// ellipsoid. It is used by [Trilaterator.Trilaterate3D] and by
+	// [Trilaterator.Trilaterate] with [WithTargetHeight], and ignored
+	// otherwise.
+	Alt float64
 	// Distance is the measured distance from the reference point to the target in meters.
 	Distance float64
 	// Weight indicates the measurement's reliability (higher = more trusted).
@@ -49,15 +54,30 @@ type TrilateratorConfig struct {
 	// MinMeasurements is the maximum number of measurements allowed.
 	// Defaults to 3.
 	MinMeasurements int
+	// MaxMeasurements3D is the maximum number of measurements allowed by
+	// [Trilaterator.Trilaterate3D], which needs at least 3. Defaults to 8.
+	MaxMeasurements3D int
+	// TargetHeight is the known altitude of the target in meters above the
+	// WGS-84 ellipsoid. If set, Trilaterate treats the distances as slant
+	// ranges from anchors at the altitude Alt and projects them onto the
+	// horizontal plane. Defaults to nil, which uses the distances as they are.
+	TargetHeight *float64
 }
 
+// ErrRangeTooShort is returned by [Trilaterator.Trilaterate] with
+// [WithTargetHeight] for a measured range that is shorter than the height
+// difference between its anchor and the target, which is geometrically
+// impossible and usually caused by measurement noise or a wrong altitude.
+var ErrRangeTooShort = errors.New("range shorter than height difference")
+
 // NewTrilaterator creates a new Trilaterator with the given options.
 // By default, it uses [distance.HaversineDistance] for distance calculations
-// and allows up to 3 measurements.
+// and allows up to 3 measurements, or up to 8 in three dimensions.
 func NewTrilaterator(opts ...TrilateratorOpt) *Trilaterator {
 	config := &TrilateratorConfig{
-		DistanceFunc:    distance.HaversineDistance,
-		MinMeasurements: 3,
+		DistanceFunc:      distance.HaversineDistance,
+		MinMeasurements:   3,
+		MaxMeasurements3D: 8,
 	}
 
 	for _, opt := range opts {
@@ -69,6 +89,33 @@ func NewTrilaterator(opts ...TrilateratorOpt) *Trilaterator {
 	}
 }
 
+// Result is the outcome of [Trilaterator.Solve] with the details of the
+// optimization, for example to log or debug poor position estimates.
+type Result struct {
+	// Position is the estimated position.
+	Position polaris.Position
+	// Accuracy is the accuracy metric returned by [Trilaterator.Trilaterate]
+	// in meters.
+	Accuracy float64
+	// Residuals holds, for each measurement, the distance from Position to its
+	// reference point minus the measured distance, in meters. Positive
+	// residuals mean that the measured distance is too short for the
+	// estimate. With [WithTargetHeight] they refer to horizontal distances.
+	Residuals []float64
+	// Cost is the weighted sum of squared residuals in square meters that
+	// the optimization minimized.
+	Cost float64
+	// Initial is the initial guess, the weighted mean of the reference
+	// points.
+	Initial polaris.Position
+	// Iterations is the number of major iterations of the optimizer.
+	Iterations int
+	// FuncEvaluations is the number of evaluations of the cost function.
+	FuncEvaluations int
+	// Status is the reason the optimizer stopped.
+	Status optimize.Status
+}
+
 // Trilaterate estimates a position from the given distance measurements using
 // weighted least-squares optimization. It returns the estimated position,
 // an accuracy metric (weighted RMS error in meters), and any error encountered.
@@ -78,27 +125,66 @@ func NewTrilaterator(opts ...TrilateratorOpt) *Trilaterator {
 // With multiple measurements, it uses the Nelder-Mead algorithm to find the
 // position that minimizes the weighted sum of squared distance errors.
 //
-// All measurements must have positive weights and non-negative distances.
+// All measurements must have valid coordinates as defined by
+// [polaris.Position.Validate], positive weights and non-negative distances.
+//
+// With [WithTargetHeight], each distance is a slant range from an anchor at
+// altitude Alt that is converted to the horizontal distance
+// sqrt(Distance² - (Alt - TargetHeight)²) before solving, so the accuracy
+// refers to horizontal distances as well. A range shorter than the height
+// difference returns an error wrapping [ErrRangeTooShort], and a NaN or
+// infinite TargetHeight one wrapping [polaris.ErrInvalidAltitude].
+//
+// Use [Trilaterator.Solve] for the residuals and the state of the optimizer.
 func (t *Trilaterator) Trilaterate(measurements []Measurement) (loc polaris.Position, accuracy float64, err error) {
+	result, err := t.Solve(measurements)
+	if err != nil {
+		return polaris.EmptyPosition, 0, err
+	}
+	return result.Position, result.Accuracy, nil
+}
+
+// Solve estimates a position like [Trilaterator.Trilaterate] and returns it
+// together with the residuals of the measurements, the final cost, the
+// initial guess and the statistics and termination status of the optimizer.
+//
+// If the optimizer fails, Solve returns the error together with the result
+// reached so far. With a single measurement no optimization runs, so the
+// iterations and function evaluations are zero and the status is
+// [optimize.NotTerminated].
+func (t *Trilaterator) Solve(measurements []Measurement) (Result, error) {
 
 	if len(measurements) < 1 || len(measurements) > t.config.MinMeasurements {
-		return polaris.EmptyPosition, 0, fmt.Errorf("must provide 1-%d measurements", t.config.MinMeasurements)
+		return Result{}, fmt.Errorf("must provide 1-%d measurements", t.config.MinMeasurements)
 	}
 
-	if len(measurements) == 1 {
-		return polaris.NewPosition(measurements[0].Lat, measurements[0].Lon), measurements[0].Distance, nil
+	for i, m := range measurements {
+		if err := polaris.NewPosition(m.Lat, m.Lon).Validate(); err != nil {
+			return Result{}, fmt.Errorf("measurement %d: %w", i, err)
+		}
 	}
 
-	for _, m := range measurements {
-		if m.Weight <= 0 {
-			return polaris.EmptyPosition, 0, errors.New("weights must be positive")
+	if t.config.TargetHeight != nil {
+		var err error
+		if measurements, err = horizontalRanges(measurements, *t.config.TargetHeight); err != nil {
+			return Result{}, err
 		}
 	}
 
-	for _, m := range measurements {
-		if m.Distance < 0 {
-			return polaris.EmptyPosition, 0, errors.New("distances must be positive")
-		}
+	if len(measurements) == 1 {
+		m := measurements[0]
+		anchor := polaris.NewPosition(m.Lat, m.Lon)
+		return Result{
+			Position:  anchor,
+			Accuracy:  m.Distance,
+			Residuals: []float64{-m.Distance},
+			Cost:      m.Weight * m.Distance * m.Distance,
+			Initial:   anchor,
+		}, nil
+	}
+
+	if err := checkMeasurements(measurements); err != nil {
+		return Result{}, err
 	}
 
 	// Initial guess: average of beacon positions
@@ -129,11 +215,51 @@ func (t *Trilaterator) Trilaterate(measurements []Measurement) (loc polaris.Posi
 
 	// Run optimization
 	result, err := optimize.Minimize(problem, []float64{initLat, initLong}, nil, &optimize.NelderMead{})
-	if err != nil {
-		return polaris.EmptyPosition, 0, err
+	if result == nil {
+		return Result{}, err
+	}
+
+	loc := polaris.NewPosition(result.X[0], result.X[1])
+	residuals := make([]float64, len(measurements))
+	for i, m := range measurements {
+		residuals[i] = t.config.DistanceFunc(loc, polaris.NewPosition(m.Lat, m.Lon)) - m.Distance
 	}
 
 	weightedSquareError := result.F
 	weightedError := math.Sqrt(weightedSquareError) / float64(len(measurements))
-	return polaris.NewPosition(result.X[0], result.X[1]), weightedError, nil
+	return Result{
+		Position:        loc,
+		Accuracy:        weightedError,
+		Residuals:       residuals,
+		Cost:            result.F,
+		Initial:         polaris.NewPosition(initLat, initLong),
+		Iterations:      result.MajorIterations,
+		FuncEvaluations: result.FuncEvaluations,
+		Status:          result.Status,
+	}, err
+}
+
+// horizontalRanges returns a copy of the measurements with the slant ranges
+// from anchors at altitude Alt to a target at the given height converted to
+// horizontal distances.
+func horizontalRanges(measurements []Measurement, height float64) ([]Measurement, error) {
+	if math.IsNaN(height) || math.IsInf(height, 0) {
+		return nil, fmt.Errorf("target height: %w %v: not finite", polaris.ErrInvalidAltitude, height)
+	}
+	result := make([]Measurement, len(measurements))
+	for i, m := range measurements {
+		if err := polaris.NewPosition3D(m.Lat, m.Lon, m.Alt).Validate(); err != nil {
+			return nil, fmt.Errorf("measurement %d: %w", i, err)
+		}
+		dh := math.Abs(m.Alt - height)
+		// Negative distances are left to checkMeasurements.
+		if m.Distance >= 0 && m.Distance < dh {
+			return nil, fmt.Errorf("measurement %d: %w: %v m < %v m", i, ErrRangeTooShort, m.Distance, dh)
+		}
+		if m.Distance >= 0 {
+			m.Distance = math.Sqrt(m.Distance*m.Distance - dh*dh)
+		}
+		result[i] = m
+	}
+	return result, nil
 }
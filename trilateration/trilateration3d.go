@@ -0,0 +1,242 @@
+package trilateration
+
+import (
+	"errors"
+	"fmt"
+	"math"
+
+	"gonum.org/v1/gonum/mat"
+	"gonum.org/v1/gonum/optimize"
+
+	"github.com/ethz-polymaps/polaris"
+	"github.com/ethz-polymaps/polaris/projection"
+)
+
+// Trilaterate3D estimates a position together with its altitude from the
+// given slant-range measurements using weighted least-squares optimization.
+// The anchor altitudes are taken from [Measurement.Alt] and the returned
+// altitude is in meters above the WGS-84 ellipsoid as well.
+//
+// The problem is solved in a local East-North-Up frame around the anchors, in
+// which the distances are exact straight-line distances, so the configured
+// DistanceFunc is not used. It requires 3 to MaxMeasurements3D measurements
+// with valid coordinates and finite altitudes, positive weights and
+// non-negative distances.
+//
+// It returns the horizontal and vertical accuracy in meters: the weighted RMS
+// error of the ranges, sqrt(Σ wᵢrᵢ² / Σ wᵢ) for residuals rᵢ and weights wᵢ,
+// scaled by the horizontal and vertical dilution of precision of the anchor
+// geometry. This differs from the accuracy of [Trilaterator.Trilaterate],
+// sqrt(Σ wᵢrᵢ²) / n, which neither normalizes the weights nor accounts for
+// the geometry, so the two values are not comparable. Anchors that are almost
+// coplanar constrain the altitude of positions near their plane poorly, which
+// shows up as a large vertical accuracy rather than a failed solve; if it
+// cannot be determined at all the vertical accuracy is +Inf.
+//
+// Ranges from anchors in one plane, which includes any three anchors, cannot
+// tell a position from its mirror image across that plane. The solver starts
+// on both sides of the anchors, searches again from the mirror image of the
+// best fit and keeps the best of these. If a position and its mirror image fit
+// equally well, it returns the lower one, as for anchors mounted on ceilings.
+// A fourth anchor at a different height resolves the ambiguity.
+func (t *Trilaterator) Trilaterate3D(measurements []Measurement) (loc polaris.Position3D, horizontal, vertical float64, err error) {
+
+	limit := t.config.MaxMeasurements3D
+	if limit < 3 {
+		return polaris.Position3D{}, 0, 0, fmt.Errorf("3D trilateration needs at least 3 measurements, but at most %d are allowed", limit)
+	}
+	if len(measurements) < 3 || len(measurements) > limit {
+		return polaris.Position3D{}, 0, 0, fmt.Errorf("must provide 3-%d measurements", limit)
+	}
+
+	for i, m := range measurements {
+		if err := polaris.NewPosition3D(m.Lat, m.Lon, m.Alt).Validate(); err != nil {
+			return polaris.Position3D{}, 0, 0, fmt.Errorf("measurement %d: %w", i, err)
+		}
+	}
+
+	if err := checkMeasurements(measurements); err != nil {
+		return polaris.Position3D{}, 0, 0, err
+	}
+
+	// The frame is centered on the weighted mean of the anchors, computed in
+	// Earth-centered coordinates so that it works at any longitude.
+	var center projection.ECEF
+	totalWeight, meanDistance, sumSquares := 0.0, 0.0, 0.0
+	for _, m := range measurements {
+		c := projection.ToECEF(polaris.NewPosition(m.Lat, m.Lon), m.Alt)
+		center.X += c.X * m.Weight
+		center.Y += c.Y * m.Weight
+		center.Z += c.Z * m.Weight
+		totalWeight += m.Weight
+		meanDistance += m.Distance * m.Weight
+		sumSquares += m.Weight * m.Distance * m.Distance
+	}
+	center.X /= totalWeight
+	center.Y /= totalWeight
+	center.Z /= totalWeight
+	meanDistance /= totalWeight
+	frame := projection.NewLocalFrame(projection.FromECEF(center))
+
+	anchors := make([]projection.ENU, len(measurements))
+	for i, m := range measurements {
+		anchors[i] = frame.ToENU(polaris.NewPosition(m.Lat, m.Lon), m.Alt)
+	}
+
+	problem := optimize.Problem{
+		Func: func(x []float64) float64 {
+			var sum float64
+			for i, m := range measurements {
+				diff := slantDistance(x, anchors[i]) - m.Distance
+				sum += m.Weight * diff * diff
+			}
+			return sum
+		},
+	}
+
+	// Start below and above the anchors, at about the distance of the
+	// target, so that the solver does not get stuck in the plane of
+	// coplanar anchors where the altitude has no influence on the ranges.
+	// Either start may cross the plane of the anchors, so the other side is
+	// searched again from the mirror image of the best fit. Costs closer
+	// than tie, relative to the scale of the squared ranges, are equal to
+	// within the convergence of the optimizer.
+	offset := math.Max(meanDistance, 1)
+	tie := 1e-6 * sumSquares
+	var best *optimize.Result
+	for _, up := range []float64{-offset, offset} {
+		result, err := optimize.Minimize(problem, []float64{0, 0, up}, nil, &optimize.NelderMead{})
+		if err != nil {
+			return polaris.Position3D{}, 0, 0, err
+		}
+		best = better(best, result, tie)
+	}
+	result, err := optimize.Minimize(problem, mirror(best.X, anchors), nil, &optimize.NelderMead{})
+	if err != nil {
+		return polaris.Position3D{}, 0, 0, err
+	}
+	best = better(best, result, tie)
+
+	x := best.X
+	p, h := frame.FromENU(projection.ENU{East: x[0], North: x[1], Up: x[2]})
+	hdop, vdop := dilution(x, anchors, measurements)
+	rms := math.Sqrt(best.F / totalWeight)
+	return polaris.Position3D{Position: p, Altitude: h}, accuracy(rms, hdop), accuracy(rms, vdop), nil
+}
+
+// better returns the result that fits the ranges better. If the costs are
+// within tie of each other, it returns the one with the lower Up coordinate.
+func better(best, result *optimize.Result, tie float64) *optimize.Result {
+	switch {
+	case best == nil, result.F < best.F-tie:
+		return result
+	case result.F <= best.F+tie && result.X[2] < best.X[2]:
+		return result
+	}
+	return best
+}
+
+// mirror returns the mirror image of the point x across the plane that fits
+// the anchors best. For anchors in one plane, the mirror image fits the
+// ranges exactly as well as x.
+func mirror(x []float64, anchors []projection.ENU) []float64 {
+	var c projection.ENU
+	for _, a := range anchors {
+		c.East += a.East / float64(len(anchors))
+		c.North += a.North / float64(len(anchors))
+		c.Up += a.Up / float64(len(anchors))
+	}
+	centered := mat.NewDense(len(anchors), 3, nil)
+	for i, a := range anchors {
+		centered.SetRow(i, []float64{a.East - c.East, a.North - c.North, a.Up - c.Up})
+	}
+
+	// The normal of the plane is the direction of least spread, the right
+	// singular vector of the smallest singular value.
+	var svd mat.SVD
+	if !svd.Factorize(centered, mat.SVDThinV) {
+		return x
+	}
+	var v mat.Dense
+	svd.VTo(&v)
+	n := [3]float64{v.At(0, 2), v.At(1, 2), v.At(2, 2)}
+
+	d := (x[0]-c.East)*n[0] + (x[1]-c.North)*n[1] + (x[2]-c.Up)*n[2]
+	return []float64{x[0] - 2*d*n[0], x[1] - 2*d*n[1], x[2] - 2*d*n[2]}
+}
+
+// slantDistance returns the distance in meters between the point x given by
+// its East, North and Up coordinates and an anchor.
+func slantDistance(x []float64, anchor projection.ENU) float64 {
+	return projection.ENU{East: x[0] - anchor.East, North: x[1] - anchor.North, Up: x[2] - anchor.Up}.Norm()
+}
+
+// dilution returns the horizontal and vertical dilution of precision of the
+// anchors as seen from x: the factors by which the weighted range errors
+// translate into position errors. A direction that the ranges do not
+// constrain has a dilution of +Inf.
+func dilution(x []float64, anchors []projection.ENU, measurements []Measurement) (hdop, vdop float64) {
+	// The normal matrix of the linearized problem, with weights relative to
+	// their mean so that the dilution does not depend on their scale.
+	meanWeight := 0.0
+	for _, m := range measurements {
+		meanWeight += m.Weight
+	}
+	meanWeight /= float64(len(measurements))
+
+	normal := mat.NewSymDense(3, nil)
+	for i, m := range measurements {
+		r := slantDistance(x, anchors[i])
+		if r == 0 {
+			continue
+		}
+		u := mat.NewVecDense(3, []float64{
+			(x[0] - anchors[i].East) / r,
+			(x[1] - anchors[i].North) / r,
+			(x[2] - anchors[i].Up) / r,
+		})
+		normal.SymRankOne(normal, m.Weight/meanWeight, u)
+	}
+
+	var chol mat.Cholesky
+	if chol.Factorize(normal) {
+		var cov mat.SymDense
+		if err := chol.InverseTo(&cov); err == nil {
+			return math.Sqrt(cov.At(0, 0) + cov.At(1, 1)), math.Sqrt(cov.At(2, 2))
+		}
+	}
+
+	// The altitude is undetermined, but the horizontal position may still
+	// be fixed by the East-North block on its own.
+	ee, nn, en := normal.At(0, 0), normal.At(1, 1), normal.At(0, 1)
+	if det := ee*nn - en*en; det > 0 {
+		return math.Sqrt((ee + nn) / det), math.Inf(1)
+	}
+	return math.Inf(1), math.Inf(1)
+}
+
+// accuracy scales the RMS range error by a dilution of precision. An
+// undetermined direction has an accuracy of +Inf even for error-free ranges.
+func accuracy(rms, dop float64) float64 {
+	if math.IsInf(dop, 1) {
+		return dop
+	}
+	return rms * dop
+}
+
+// checkMeasurements reports whether all weights are positive and all
+// distances non-negative.
+func checkMeasurements(measurements []Measurement) error {
+	for _, m := range measurements {
+		if m.Weight <= 0 {
+			return errors.New("weights must be positive")
+		}
+	}
+
+	for _, m := range measurements {
+		if m.Distance < 0 {
+			return errors.New("distances must be positive")
+		}
+	}
+	return nil
+}
@@ -0,0 +1,222 @@
+package trilateration
+
+import (
+	"math"
+	"math/rand/v2"
+	"slices"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/ethz-polymaps/polaris"
+	"github.com/ethz-polymaps/polaris/distance"
+	"github.com/ethz-polymaps/polaris/projection"
+)
+
+// anchorsAround returns measurements from anchors at the given bearings,
+// horizontal distances and altitudes around center, with the exact slant
+// ranges to target.
+func anchorsAround(center polaris.Position, target polaris.Position3D, bearings, meters, altitudes []float64) []Measurement {
+	measurements := make([]Measurement, len(bearings))
+	for i := range bearings {
+		anchor, _ := distance.VincentyDestination(center, bearings[i], meters[i])
+		measurements[i] = Measurement{
+			Lat:      anchor.Latitude,
+			Lon:      anchor.Longitude,
+			Alt:      altitudes[i],
+			Distance: distance.SlantDistance(polaris.Position3D{Position: anchor, Altitude: altitudes[i]}, target),
+			Weight:   1.0,
+		}
+	}
+	return measurements
+}
+
+func TestTrilaterate3D(t *testing.T) {
+	center := polaris.NewPosition(47.41328, 8.53646)
+	tests := []struct {
+		name      string
+		target    polaris.Position3D
+		bearings  []float64
+		meters    []float64
+		altitudes []float64
+	}{
+		{
+			name:      "drone above ground anchors and a mast",
+			target:    polaris.Position3D{Position: polaris.NewPosition(47.4134, 8.5366), Altitude: 480},
+			bearings:  []float64{0, 120, 240, 0},
+			meters:    []float64{150, 150, 150, 0},
+			altitudes: []float64{430, 432, 429, 470},
+		},
+		{
+			name:      "tag below ceiling anchors",
+			target:    polaris.Position3D{Position: polaris.NewPosition(47.41330, 8.53649), Altitude: 410},
+			bearings:  []float64{0, 120, 240},
+			meters:    []float64{8, 8, 8},
+			altitudes: []float64{413.5, 413.5, 413.5},
+		},
+		{
+			name:      "drone above coplanar ground anchors with a mast",
+			target:    polaris.Position3D{Position: polaris.NewPosition(47.41328, 8.53646), Altitude: 520},
+			bearings:  []float64{45, 135, 225, 315, 0},
+			meters:    []float64{200, 200, 200, 200, 0},
+			altitudes: []float64{430, 430, 430, 430, 445},
+		},
+		{
+			name:      "near the antimeridian",
+			target:    polaris.Position3D{Position: polaris.NewPosition(-16.5, 179.9999), Altitude: 12},
+			bearings:  []float64{0, 90, 180, 270},
+			meters:    []float64{30, 30, 30, 30},
+			altitudes: []float64{20, 15, 18, 14},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			c := center
+			if tt.target.Longitude > 170 {
+				c = polaris.NewPosition(-16.5, 180)
+			}
+			measurements := anchorsAround(c, tt.target, tt.bearings, tt.meters, tt.altitudes)
+
+			tri := NewTrilaterator()
+			loc, horizontal, vertical, err := tri.Trilaterate3D(measurements)
+			require.NoError(t, err)
+
+			assert.Less(t, distance.KarneyDistance(tt.target.Position, loc.Position), 0.001)
+			assert.InDelta(t, tt.target.Altitude, loc.Altitude, 0.001)
+			assert.Less(t, horizontal, 0.01)
+			assert.Less(t, vertical, 0.01)
+		})
+	}
+}
+
+func TestTrilaterate3D_nearlyCoplanar(t *testing.T) {
+	// Ceiling anchors whose heights differ by a few centimeters, with noisy
+	// ranges to a tag 1.5 m above the floor.
+	center := polaris.NewPosition(47.41328, 8.53646)
+	target := polaris.Position3D{Position: polaris.NewPosition(47.41330, 8.53649), Altitude: 409.5}
+	measurements := anchorsAround(center, target,
+		[]float64{0, 60, 120, 180, 240, 300},
+		[]float64{10, 12, 9, 11, 10, 12},
+		[]float64{413.52, 413.48, 413.50, 413.53, 413.47, 413.51},
+	)
+	r := rand.New(rand.NewPCG(3, 4))
+	for i := range measurements {
+		measurements[i].Distance += r.NormFloat64() * 0.05
+	}
+
+	tri := NewTrilaterator()
+	loc, horizontal, vertical, err := tri.Trilaterate3D(measurements)
+	require.NoError(t, err)
+
+	assert.Less(t, distance.KarneyDistance(target.Position, loc.Position), 0.2)
+	assert.InDelta(t, target.Altitude, loc.Altitude, 0.3)
+	assert.Less(t, loc.Altitude, 413.5, "below the ceiling")
+	assert.Greater(t, horizontal, 0.0)
+	assert.Greater(t, vertical, horizontal)
+	assert.False(t, math.IsInf(vertical, 0))
+}
+
+func TestTrilaterate3D_mirror(t *testing.T) {
+	// Three ceiling anchors cannot tell a tag below them from its mirror
+	// image above, which fits the ranges equally well.
+	center := polaris.NewPosition(47.41328, 8.53646)
+	r := rand.New(rand.NewPCG(1, 2))
+	tri := NewTrilaterator()
+	for i := range 100 {
+		tag, _ := distance.VincentyDestination(center, r.Float64()*360, r.Float64()*10)
+		target := polaris.Position3D{Position: tag, Altitude: 410 + r.Float64()}
+		bearing := r.Float64() * 360
+		measurements := anchorsAround(center, target,
+			[]float64{bearing, bearing + 100 + r.Float64()*40, bearing + 220 + r.Float64()*40},
+			[]float64{5 + r.Float64()*10, 5 + r.Float64()*10, 5 + r.Float64()*10},
+			[]float64{413.5, 413.5, 413.5},
+		)
+
+		loc, _, _, err := tri.Trilaterate3D(measurements)
+		require.NoError(t, err)
+		assert.Less(t, loc.Altitude, 413.5, "target %d below the ceiling", i)
+		assert.InDelta(t, target.Altitude, loc.Altitude, 0.01, "target %d", i)
+	}
+}
+
+func TestTrilaterate3D_invalid(t *testing.T) {
+	valid := []Measurement{
+		{Lat: 47.4133, Lon: 8.5364, Alt: 413, Distance: 10, Weight: 1.0},
+		{Lat: 47.4132, Lon: 8.5364, Alt: 413, Distance: 10, Weight: 1.0},
+		{Lat: 47.4133, Lon: 8.5365, Alt: 413, Distance: 10, Weight: 1.0},
+	}
+	with := func(i int, f func(m *Measurement)) []Measurement {
+		ms := append([]Measurement(nil), valid...)
+		f(&ms[i])
+		return ms
+	}
+
+	tests := []struct {
+		name         string
+		measurements []Measurement
+		wantErr      error
+		wantMessage  string
+	}{
+		{name: "too few", measurements: valid[:2], wantMessage: "must provide 3-8 measurements"},
+		{name: "too many", measurements: slices.Concat(valid, valid, valid), wantMessage: "must provide 3-8 measurements"},
+		{name: "latitude out of range", measurements: with(1, func(m *Measurement) { m.Lat = 95 }), wantErr: polaris.ErrInvalidLatitude},
+		{name: "altitude NaN", measurements: with(2, func(m *Measurement) { m.Alt = math.NaN() }), wantErr: polaris.ErrInvalidAltitude, wantMessage: "measurement 2: invalid altitude NaN: not finite"},
+		{name: "zero weight", measurements: with(0, func(m *Measurement) { m.Weight = 0 }), wantMessage: "weights must be positive"},
+		{name: "negative distance", measurements: with(0, func(m *Measurement) { m.Distance = -1 }), wantMessage: "distances must be positive"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, _, _, err := NewTrilaterator().Trilaterate3D(tt.measurements)
+			require.Error(t, err)
+			if tt.wantErr != nil {
+				assert.ErrorIs(t, err, tt.wantErr)
+			}
+			if tt.wantMessage != "" {
+				assert.EqualError(t, err, tt.wantMessage)
+			}
+		})
+	}
+}
+
+func TestTrilaterate3D_limit(t *testing.T) {
+	measurements := []Measurement{
+		{Lat: 47.4133, Lon: 8.5364, Alt: 413, Distance: 10, Weight: 1.0},
+		{Lat: 47.4132, Lon: 8.5364, Alt: 413, Distance: 10, Weight: 1.0},
+		{Lat: 47.4133, Lon: 8.5365, Alt: 413, Distance: 10, Weight: 1.0},
+		{Lat: 47.4132, Lon: 8.5365, Alt: 416, Distance: 10, Weight: 1.0},
+	}
+
+	// The 2D limit does not apply.
+	_, _, _, err := NewTrilaterator(WithMaxMeasurements(2)).Trilaterate3D(measurements)
+	assert.NoError(t, err)
+
+	_, _, _, err = NewTrilaterator(WithMaxMeasurements3D(3)).Trilaterate3D(measurements)
+	assert.EqualError(t, err, "must provide 3-3 measurements")
+
+	_, _, _, err = NewTrilaterator(WithMaxMeasurements3D(2)).Trilaterate3D(measurements[:3])
+	assert.EqualError(t, err, "3D trilateration needs at least 3 measurements, but at most 2 are allowed")
+}
+
+func TestDilution(t *testing.T) {
+	anchors := []projection.ENU{{East: 10}, {North: 10}, {East: -10}, {North: -10}}
+	measurements := []Measurement{{Weight: 1}, {Weight: 1}, {Weight: 1}, {Weight: 1}}
+
+	// Straight below the center of a square at 45° elevation, every range
+	// constrains the horizontal and vertical position equally.
+	hdop, vdop := dilution([]float64{0, 0, -10}, anchors, measurements)
+	assert.InDelta(t, math.Sqrt2, hdop, 1e-12)
+	assert.InDelta(t, 1/math.Sqrt2, vdop, 1e-12)
+
+	// In the plane of the anchors the altitude is undetermined.
+	hdop, vdop = dilution([]float64{0, 0, 0}, anchors, measurements)
+	assert.InDelta(t, 1, hdop, 1e-12)
+	assert.True(t, math.IsInf(vdop, 1))
+
+	// Doubling all weights does not change the geometry.
+	for i := range measurements {
+		measurements[i].Weight = 2
+	}
+	hdop, _ = dilution([]float64{0, 0, -10}, anchors, measurements)
+	assert.InDelta(t, math.Sqrt2, hdop, 1e-12)
+}
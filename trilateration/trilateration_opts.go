@@ -11,3 +11,38 @@ func WithDistanceFunc(distanceFunc func(a polaris.Position, b polaris.Position)
 		t.DistanceFunc = distanceFunc
 	}
 }
+
+// WithMaxMeasurements sets the maximum number of measurements accepted by
+// [Trilaterator.Trilaterate] and [Trilaterator.Solve], stored in the
+// MinMeasurements field of the configuration. Raise it to use more anchors:
+//
+//	t := NewTrilaterator(WithMaxMeasurements(8))
+func WithMaxMeasurements(n int) TrilateratorOpt {
+	return func(t *TrilateratorConfig) {
+		t.MinMeasurements = n
+	}
+}
+
+// WithMaxMeasurements3D sets the maximum number of measurements accepted by
+// [Trilaterator.Trilaterate3D]. Values below 3 make every call fail, as three
+// dimensions need at least 3 measurements.
+//
+//	t := NewTrilaterator(WithMaxMeasurements3D(16))
+func WithMaxMeasurements3D(n int) TrilateratorOpt {
+	return func(t *TrilateratorConfig) {
+		t.MaxMeasurements3D = n
+	}
+}
+
+// WithTargetHeight sets the known altitude of the target in meters above the
+// WGS-84 ellipsoid, for example a forklift antenna 1.5 m above a floor at
+// 408 m. [Trilaterator.Trilaterate] then projects the slant ranges from the
+// anchors at their altitude Alt onto the horizontal plane before solving in
+// two dimensions:
+//
+//	t := NewTrilaterator(WithTargetHeight(409.5))
+func WithTargetHeight(h float64) TrilateratorOpt {
+	return func(t *TrilateratorConfig) {
+		t.TargetHeight = &h
+	}
+}
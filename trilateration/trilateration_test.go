@@ -1,11 +1,14 @@
 package trilateration
 
 import (
+	"math"
 	"testing"
 
+	"github.com/ethz-polymaps/polaris"
 	"github.com/ethz-polymaps/polaris/distance"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
+	"gonum.org/v1/gonum/optimize"
 )
 
 func TestTrilaterate(t *testing.T) {
@@ -42,4 +45,262 @@ func TestTrilaterate(t *testing.T) {
 
 	})
 
+	t.Run("ruler", func(t *testing.T) {
+
+		ruler := distance.NewRuler(measurements[0].Lat)
+		tri := NewTrilaterator(WithDistanceFunc(ruler.Distance))
+
+		loc, accuracy, err := tri.Trilaterate(measurements)
+		require.NoError(t, err)
+
+		// Within a few meters the ruler matches the ellipsoidal distances.
+		assert.InDelta(t, 47.4132769158433, loc.Latitude, 0.0000001)
+		assert.InDelta(t, 8.536464546559099, loc.Longitude, 0.0000001)
+		assert.InDelta(t, 2.639501187168186, accuracy, 0.001)
+
+	})
+
+}
+
+func TestTrilaterate_invalidCoordinates(t *testing.T) {
+	tests := []struct {
+		name         string
+		measurements []Measurement
+		wantErr      error
+	}{
+		{
+			name: "latitude out of range",
+			measurements: []Measurement{
+				{Lat: 47.4133, Lon: 8.5364, Distance: 10, Weight: 1.0},
+				{Lat: 95, Lon: 8.5364, Distance: 10, Weight: 1.0},
+			},
+			wantErr: polaris.ErrInvalidLatitude,
+		},
+		{
+			name: "longitude NaN",
+			measurements: []Measurement{
+				{Lat: 47.4133, Lon: math.NaN(), Distance: 10, Weight: 1.0},
+				{Lat: 47.4132, Lon: 8.5364, Distance: 10, Weight: 1.0},
+			},
+			wantErr: polaris.ErrInvalidLongitude,
+		},
+		{
+			name: "single measurement",
+			measurements: []Measurement{
+				{Lat: 47.4133, Lon: 190, Distance: 10, Weight: 1.0},
+			},
+			wantErr: polaris.ErrInvalidLongitude,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, _, err := NewTrilaterator().Trilaterate(tt.measurements)
+			assert.ErrorIs(t, err, tt.wantErr)
+		})
+	}
+}
+
+func TestTrilaterate_slantRanges(t *testing.T) {
+	// Anchors on a ceiling 3.5 m above the tag measure slant ranges, which
+	// are biased when they are matched against ground distances.
+	tag := polaris.NewPosition3D(47.41328, 8.53646, 409.5)
+	const height = 3.5
+	var measurements []Measurement
+	for _, bearing := range []float64{0, 120, 240} {
+		anchor, _ := distance.VincentyDestination(tag.Position, bearing, 8)
+		measurements = append(measurements, Measurement{
+			Lat:      anchor.Latitude,
+			Lon:      anchor.Longitude,
+			Distance: distance.SlantDistance(polaris.Position3D{Position: anchor, Altitude: tag.Altitude + height}, tag),
+			Weight:   1.0,
+		})
+	}
+
+	tri := NewTrilaterator(WithDistanceFunc(distance.NewSlant(distance.VincentyDistance, tag.Altitude, height)))
+	loc, accuracy, err := tri.Trilaterate(measurements)
+	require.NoError(t, err)
+	assert.Less(t, distance.VincentyDistance(tag.Position, loc), 0.01)
+	assert.Less(t, accuracy, 0.01)
+}
+
+func TestTrilaterate_targetHeight(t *testing.T) {
+	// Ceiling anchors 4 m above a forklift antenna at a known height.
+	tag := polaris.NewPosition(47.41328, 8.53646)
+	const antenna = 409.5
+	altitudes := []float64{413.5, 413.4, 413.6}
+	var measurements []Measurement
+	for i, bearing := range []float64{0, 120, 240} {
+		anchor, _ := distance.VincentyDestination(tag, bearing, 6)
+		measurements = append(measurements, Measurement{
+			Lat:      anchor.Latitude,
+			Lon:      anchor.Longitude,
+			Alt:      altitudes[i],
+			Distance: distance.SlantDistance(polaris.Position3D{Position: anchor, Altitude: altitudes[i]}, polaris.Position3D{Position: tag, Altitude: antenna}),
+			Weight:   1.0,
+		})
+	}
+	original := append([]Measurement(nil), measurements...)
+
+	tri := NewTrilaterator(WithDistanceFunc(distance.VincentyDistance), WithTargetHeight(antenna))
+	loc, accuracy, err := tri.Trilaterate(measurements)
+	require.NoError(t, err)
+	assert.Less(t, distance.VincentyDistance(tag, loc), 0.01)
+	assert.Less(t, accuracy, 0.01)
+	assert.Equal(t, original, measurements, "measurements are not modified")
+
+	// Matching the slant ranges against ground distances is off by more
+	// than a meter.
+	_, accuracy, err = NewTrilaterator(WithDistanceFunc(distance.VincentyDistance)).Trilaterate(measurements)
+	require.NoError(t, err)
+	assert.Greater(t, accuracy, 0.5)
+
+	// A single measurement returns its anchor with the horizontal distance.
+	_, accuracy, err = tri.Trilaterate(measurements[:1])
+	require.NoError(t, err)
+	assert.InDelta(t, 6, accuracy, 0.001)
+}
+
+func TestTrilaterate_targetHeightInvalid(t *testing.T) {
+	tests := []struct {
+		name         string
+		measurements []Measurement
+		wantErr      error
+		wantMessage  string
+	}{
+		{
+			name: "range shorter than height difference",
+			measurements: []Measurement{
+				{Lat: 47.4133, Lon: 8.5364, Alt: 413.5, Distance: 5, Weight: 1.0},
+				{Lat: 47.4132, Lon: 8.5364, Alt: 413.5, Distance: 3.9, Weight: 1.0},
+			},
+			wantErr:     ErrRangeTooShort,
+			wantMessage: "measurement 1: range shorter than height difference: 3.9 m < 4 m",
+		},
+		{
+			name: "target above the anchor",
+			measurements: []Measurement{
+				{Lat: 47.4133, Lon: 8.5364, Alt: 405, Distance: 4, Weight: 1.0},
+			},
+			wantErr: ErrRangeTooShort,
+		},
+		{
+			name: "altitude NaN",
+			measurements: []Measurement{
+				{Lat: 47.4133, Lon: 8.5364, Alt: math.NaN(), Distance: 5, Weight: 1.0},
+			},
+			wantErr: polaris.ErrInvalidAltitude,
+		},
+		{
+			name: "negative distance",
+			measurements: []Measurement{
+				{Lat: 47.4133, Lon: 8.5364, Alt: 413.5, Distance: 5, Weight: 1.0},
+				{Lat: 47.4132, Lon: 8.5364, Alt: 413.5, Distance: -1, Weight: 1.0},
+			},
+			wantMessage: "distances must be positive",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, _, err := NewTrilaterator(WithTargetHeight(409.5)).Trilaterate(tt.measurements)
+			require.Error(t, err)
+			if tt.wantErr != nil {
+				assert.ErrorIs(t, err, tt.wantErr)
+			}
+			if tt.wantMessage != "" {
+				assert.EqualError(t, err, tt.wantMessage)
+			}
+		})
+	}
+
+	// The target height itself must be finite.
+	measurements := []Measurement{
+		{Lat: 47.4133, Lon: 8.5364, Alt: 413.5, Distance: 5, Weight: 1.0},
+		{Lat: 47.4132, Lon: 8.5364, Alt: 413.5, Distance: 5, Weight: 1.0},
+	}
+	for _, height := range []float64{math.NaN(), math.Inf(1), math.Inf(-1)} {
+		_, _, err := NewTrilaterator(WithTargetHeight(height)).Trilaterate(measurements)
+		assert.ErrorIs(t, err, polaris.ErrInvalidAltitude, "height %v", height)
+	}
+}
+
+func TestSolve(t *testing.T) {
+	measurements := []Measurement{
+		{Lat: 47.41331043239206, Lon: 8.536443579900189, Distance: 0.7686246100397739, Weight: 1.0},
+		{Lat: 47.41321841412086, Lon: 8.536437101250389, Distance: 0.8767123872968682, Weight: 2.0},
+		{Lat: 47.41330944456364, Lon: 8.536520373280595, Distance: 1.4839817889675653, Weight: 1.0},
+	}
+	tri := NewTrilaterator(WithDistanceFunc(distance.VincentyDistance))
+
+	result, err := tri.Solve(measurements)
+	require.NoError(t, err)
+
+	loc, accuracy, err := tri.Trilaterate(measurements)
+	require.NoError(t, err)
+	assert.Equal(t, loc, result.Position)
+	assert.Equal(t, accuracy, result.Accuracy)
+
+	require.Len(t, result.Residuals, len(measurements))
+	var cost float64
+	for i, m := range measurements {
+		d := distance.VincentyDistance(result.Position, polaris.NewPosition(m.Lat, m.Lon))
+		assert.InDelta(t, d-m.Distance, result.Residuals[i], 1e-12)
+		cost += m.Weight * result.Residuals[i] * result.Residuals[i]
+	}
+	assert.InDelta(t, cost, result.Cost, 1e-12)
+
+	assert.InDelta(t, (47.41331043239206+2*47.41321841412086+47.41330944456364)/4, result.Initial.Latitude, 1e-12)
+	assert.InDelta(t, (8.536443579900189+2*8.536437101250389+8.536520373280595)/4, result.Initial.Longitude, 1e-12)
+	assert.Positive(t, result.Iterations)
+	assert.GreaterOrEqual(t, result.FuncEvaluations, result.Iterations)
+	assert.Equal(t, optimize.FunctionConvergence, result.Status)
+}
+
+func TestSolve_singleMeasurement(t *testing.T) {
+	result, err := NewTrilaterator().Solve([]Measurement{{Lat: 47.4133, Lon: 8.5364, Distance: 12, Weight: 0.5}})
+	require.NoError(t, err)
+	assert.Equal(t, Result{
+		Position:  polaris.NewPosition(47.4133, 8.5364),
+		Accuracy:  12,
+		Residuals: []float64{-12},
+		Cost:      72,
+		Initial:   polaris.NewPosition(47.4133, 8.5364),
+		Status:    optimize.NotTerminated,
+	}, result)
+}
+
+func TestSolve_invalid(t *testing.T) {
+	result, err := NewTrilaterator().Solve([]Measurement{
+		{Lat: 47.4133, Lon: 8.5364, Distance: 10, Weight: 1.0},
+		{Lat: 47.4132, Lon: 8.5364, Distance: 10, Weight: -1.0},
+	})
+	assert.EqualError(t, err, "weights must be positive")
+	assert.Equal(t, Result{}, result)
+}
+
+func BenchmarkTrilaterate(b *testing.B) {
+	measurements := []Measurement{
+		{Lat: 47.41331043239206, Lon: 8.536443579900189, Distance: 0.7686246100397739, Weight: 1.0},
+		{Lat: 47.41321841412086, Lon: 8.536437101250389, Distance: 0.8767123872968682, Weight: 1.0},
+		{Lat: 47.41330944456364, Lon: 8.536520373280595, Distance: 1.4839817889675653, Weight: 1.0},
+	}
+	ruler := distance.NewRuler(measurements[0].Lat)
+
+	funcs := []struct {
+		name         string
+		distanceFunc func(a, b polaris.Position) float64
+	}{
+		{"ruler", ruler.Distance},
+		{"haversine", distance.HaversineDistance},
+		{"vincenty", distance.VincentyDistance},
+	}
+	for _, f := range funcs {
+		b.Run(f.name, func(b *testing.B) {
+			tri := NewTrilaterator(WithDistanceFunc(f.distanceFunc))
+			for b.Loop() {
+				if _, _, err := tri.Trilaterate(measurements); err != nil {
+					b.Fatal(err)
+				}
+			}
+		})
+	}
 }